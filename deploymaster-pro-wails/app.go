@@ -4,15 +4,25 @@ import (
 	"bytes"
 	"context"
 	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/checksum"
 	"deploymaster-pro-wails/internal/credential"
+	"deploymaster-pro-wails/internal/health"
+	"deploymaster-pro-wails/internal/i18n"
 	"deploymaster-pro-wails/internal/node"
+	"deploymaster-pro-wails/internal/report"
+	"deploymaster-pro-wails/internal/settings"
+	"deploymaster-pro-wails/internal/sqlstore"
 	"deploymaster-pro-wails/internal/ssh"
 	"deploymaster-pro-wails/internal/svn"
 	"deploymaster-pro-wails/internal/syncd"
 	"deploymaster-pro-wails/internal/task"
+	"deploymaster-pro-wails/internal/telemetry"
+	"deploymaster-pro-wails/internal/terminal"
 	"deploymaster-pro-wails/internal/topology"
+	"deploymaster-pro-wails/internal/transfer"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -22,6 +32,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
@@ -34,17 +45,30 @@ type App struct {
 	ctx             context.Context
 	nodeService     *node.Service
 	sshTester       *ssh.Tester
+	healthMonitor   *health.Monitor
+	healthHistory   *health.History
+	telemetry       *telemetry.Collector
+	terminals       *terminal.Manager
 	topologyService *topology.Service
 	credStore       *credential.Store
 	svnService      *svn.Service
 	svnClient       *svn.Client
 	taskService     *task.Service
+	runLocker       *task.RunLocker
+	settingsService *settings.Service
+	i18n            *i18n.Localizer
+	db              *sqlstore.DB
 	dataDir         string
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	a := &App{
+		runLocker: task.NewRunLocker(),
+		i18n:      i18n.NewLocalizer(i18n.Default),
+	}
+	a.terminals = terminal.NewManager(a.emitTerminalOutput, a.emitTerminalClosed)
+	return a
 }
 
 // startup is called when the app starts. The context is saved
@@ -60,34 +84,56 @@ func (a *App) startup(ctx context.Context) {
 	}
 	a.dataDir = dataDir
 
-	storage, err := node.NewJSONStorage(dataDir)
+	// 初始化应用设置
+	a.settingsService, err = settings.NewService(dataDir)
+	if err != nil {
+		log.Printf("Failed to load settings: %v", err)
+		return
+	}
+	a.i18n.SetLang(i18n.Lang(a.settingsService.Get().Language))
+
+	stores, err := a.openStorages(dataDir)
 	if err != nil {
 		log.Printf("Failed to create storage: %v", err)
 		return
 	}
 
-	a.nodeService, err = node.NewService(storage)
+	a.nodeService, err = node.NewService(stores.node)
 	if err != nil {
 		log.Printf("Failed to create node service: %v", err)
 		return
 	}
 
 	// 初始化凭据存储
-	a.credStore = credential.NewStore(dataDir, storage.GetCrypto())
+	a.credStore = credential.NewStore(dataDir, stores.node.GetCrypto())
 
 	// 初始化SSH测试器（传入凭据存储）
-	a.sshTester = ssh.NewTester(a.credStore)
+	a.sshTester = ssh.NewTester(a.credStore, a.i18n)
+	a.sshTester.SetJumpResolver(a.jumpHosts)
 
-	// 初始化拓扑服务
-	a.topologyService = topology.NewService()
+	// 心跳历史加载失败不影响心跳本身，仅不再记录
+	a.healthHistory, err = health.NewHistory(stores.health, health.DefaultHistoryLimit)
+	if err != nil {
+		log.Printf("Failed to load health history: %v", err)
+	}
 
-	// 初始化 SVN 资源服务
-	svnStorage, err := svn.NewJSONStorage(dataDir)
+	// 启动节点心跳：定期探测所有节点，记录历史并推送 node:status 事件
+	a.healthMonitor = health.NewMonitor(a.nodeService.ListNodes, a.sshTester.QuickPing, a.onNodeStatus)
+	a.healthMonitor.Start(time.Duration(a.settingsService.Get().HeartbeatSeconds) * time.Second)
+
+	// 启动主控机资源采集并推送 node:metrics 事件
+	a.telemetry = telemetry.NewCollector(a.telemetryNodes, a.collectMetrics, a.i18n.Error, a.emitNodeMetrics)
+	a.telemetry.Start(time.Duration(a.settingsService.Get().Telemetry.IntervalSeconds) * time.Second)
+
+	// 初始化拓扑服务
+	a.topologyService, err = topology.NewService(stores.topology)
 	if err != nil {
-		log.Printf("Failed to create SVN storage: %v", err)
+		log.Printf("Failed to create topology service: %v", err)
 		return
 	}
-	a.svnService, err = svn.NewService(svnStorage)
+
+	// 初始化 SVN 资源服务
+	a.svnService, err = svn.NewService(stores.svn)
 	if err != nil {
 		log.Printf("Failed to create SVN service: %v", err)
 		return
@@ -95,20 +141,102 @@ func (a *App) startup(ctx context.Context) {
 	a.svnClient = svn.NewClient(10 * time.Second)
 
 	// 初始化任务编排服务
-	taskStorage, err := task.NewJSONStorage(dataDir)
-	if err != nil {
-		log.Printf("Failed to create task storage: %v", err)
-		return
-	}
-	a.taskService, err = task.NewService(taskStorage)
+	a.taskService, err = task.NewService(stores.task)
 	if err != nil {
 		log.Printf("Failed to create task service: %v", err)
 		return
 	}
+	if err := a.taskService.SetRetention(a.settingsService.Get().RunRetention); err != nil {
+		log.Printf("Failed to apply run retention: %v", err)
+	}
 
 	log.Println("Node topology service initialized successfully")
 }
 
+// shutdown 应用退出时释放资源
+func (a *App) shutdown(ctx context.Context) {
+	if a.healthMonitor != nil {
+		a.healthMonitor.Stop()
+	}
+	if a.telemetry != nil {
+		a.telemetry.Stop()
+	}
+	a.terminals.CloseAll()
+	if a.healthHistory != nil {
+		if err := a.healthHistory.Flush(); err != nil {
+			log.Printf("Failed to save health history: %v", err)
+		}
+	}
+	if a.db != nil {
+		_ = a.db.Close()
+	}
+}
+
+// appStorages 各服务使用的存储
+type appStorages struct {
+	node     node.Storage
+	svn      svn.Storage
+	topology topology.Storage
+	task     task.Storage
+	health   health.Storage
+}
+
+// openStorages 按设置的存储后端创建节点、SVN 资源、拓扑、任务与心跳历史存储
+// SQLite 后端首次启用时一次性导入已有 JSON 数据
+func (a *App) openStorages(dataDir string) (*appStorages, error) {
+	if a.settingsService.Get().StorageBackend != internal.StorageSQLite {
+		nodeStorage, err := node.NewJSONStorage(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		svnStorage, err := svn.NewJSONStorage(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		topologyStorage, err := topology.NewJSONStorage(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		taskStorage, err := task.NewJSONStorage(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		healthStorage, err := health.NewJSONStorage(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		return &appStorages{node: nodeStorage, svn: svnStorage, topology: topologyStorage, task: taskStorage, health: healthStorage}, nil
+	}
+
+	db, err := sqlstore.Open(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.ImportJSON(dataDir)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("import json data failed: %w", err)
+	}
+	if result.Imported {
+		log.Printf("Imported JSON data into SQLite: %d nodes, %d resources, %d topologies, %d tasks, %d templates, %d runs, %d health histories",
+			result.Nodes, result.Resources, result.Topologies, result.Tasks, result.Templates, result.Runs, result.Health)
+	}
+
+	crypto, err := node.NewCrypto(dataDir)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	a.db = db
+	return &appStorages{
+		node:     sqlstore.NewNodeStorage(db, crypto),
+		svn:      sqlstore.NewSVNStorage(db),
+		topology: sqlstore.NewTopologyStorage(db),
+		task:     sqlstore.NewTaskStorage(db),
+		health:   sqlstore.NewHealthStorage(db),
+	}, nil
+}
+
 // Greet returns a greeting for the given name
 func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
@@ -141,7 +269,21 @@ func (a *App) DeleteNode(nodeID string) error {
 	if a.credStore != nil {
 		_ = a.credStore.DeleteAll(nodeID, "")
 	}
-	return a.nodeService.DeleteNode(nodeID)
+	if err := a.nodeService.DeleteNode(nodeID); err != nil {
+		return err
+	}
+	if a.healthHistory != nil {
+		if err := a.healthHistory.Remove(nodeID); err != nil {
+			log.Printf("Failed to remove health history: %v", err)
+		}
+	}
+	if a.telemetry != nil {
+		a.telemetry.Forget(nodeID)
+	}
+	if a.topologyService != nil {
+		return a.topologyService.RemoveNode(nodeID)
+	}
+	return nil
 }
 
 // GetNodes 获取所有节点列表
@@ -160,6 +302,30 @@ func (a *App) GetNode(nodeID string) (*internal.Node, error) {
 	return a.nodeService.GetNode(nodeID)
 }
 
+// SetNodeTags 整体替换节点标签
+func (a *App) SetNodeTags(nodeID string, tags map[string]string) error {
+	if a.nodeService == nil {
+		return fmt.Errorf("node service not initialized")
+	}
+	return a.nodeService.SetNodeTags(nodeID, tags)
+}
+
+// GetNodeTags 汇总所有节点标签键及取值，用于选择器输入提示
+func (a *App) GetNodeTags() map[string][]string {
+	if a.nodeService == nil {
+		return map[string][]string{}
+	}
+	return a.nodeService.ListTags()
+}
+
+// SelectNodes 预览选择器匹配的节点
+func (a *App) SelectNodes(selector string) ([]*internal.Node, error) {
+	if a.nodeService == nil {
+		return nil, fmt.Errorf("node service not initialized")
+	}
+	return a.nodeService.SelectNodes(selector)
+}
+
 // ===== 连接测试 API =====
 
 // TestNodeConnection 测试单个节点连接
@@ -187,208 +353,612 @@ func (a *App) BatchTestConnections(username, password string) map[string]*intern
 	return a.sshTester.BatchTestConnections(nodes, username, password)
 }
 
-// ===== 拓扑数据 API =====
-
-// GetTopology 获取拓扑结构数据
-func (a *App) GetTopology() *internal.TopologyData {
-	if a.nodeService == nil || a.topologyService == nil {
-		return &internal.TopologyData{
-			Total:  0,
-			Slaves: []*internal.Node{},
-		}
+// GetNodeStatuses 返回各节点最近一次心跳探测结果
+func (a *App) GetNodeStatuses() map[string]*internal.NodeStatus {
+	if a.healthMonitor == nil {
+		return map[string]*internal.NodeStatus{}
 	}
-
-	nodes := a.nodeService.ListNodes()
-	return a.topologyService.GetTopologyData(nodes)
+	return a.healthMonitor.Statuses()
 }
 
-// ===== 凭据管理 API =====
-
-// SaveCredential 保存节点SSH密码凭据
-// rememberPassword: 是否记住密码（存储到系统密钥链）
-func (a *App) SaveCredential(nodeID, username, password string, rememberPassword bool) error {
-	if !rememberPassword {
-		// 用户选择不记住密码，不存储
-		return nil
-	}
-
-	if a.credStore == nil {
-		return fmt.Errorf("credential store not initialized")
+// RefreshNodeStatuses 立即探测所有节点，结果同时以 node:status 事件推送
+func (a *App) RefreshNodeStatuses() (map[string]*internal.NodeStatus, error) {
+	if a.healthMonitor == nil {
+		return nil, fmt.Errorf("health monitor not initialized")
 	}
-
-	return a.credStore.SetPassword(nodeID, username, password)
+	return a.healthMonitor.CheckAll(), nil
 }
 
-// SaveKeyPassphrase 保存SSH密钥的密码短语
-func (a *App) SaveKeyPassphrase(nodeID, passphrase string, rememberPassphrase bool) error {
-	if !rememberPassphrase || passphrase == "" {
-		// 不记住或密码短语为空
-		return nil
+// SetHeartbeatInterval 设置节点心跳间隔（秒），0 表示关闭，立即生效
+func (a *App) SetHeartbeatInterval(seconds int) error {
+	if a.settingsService == nil || a.healthMonitor == nil {
+		return fmt.Errorf("services not initialized")
 	}
-
-	if a.credStore == nil {
-		return fmt.Errorf("credential store not initialized")
+	if seconds < 0 {
+		return fmt.Errorf("invalid heartbeat interval")
 	}
 
-	return a.credStore.SetKeyPassphrase(nodeID, passphrase)
+	current := a.settingsService.Get()
+	current.HeartbeatSeconds = seconds
+	if err := a.settingsService.Save(current); err != nil {
+		return err
+	}
+	a.healthMonitor.Start(time.Duration(seconds) * time.Second)
+	return nil
 }
 
-// DeleteCredential 删除节点的所有凭据
-// 在删除节点或用户主动清除凭据时调用
-func (a *App) DeleteCredential(nodeID, username string) error {
-	if a.credStore == nil {
-		return fmt.Errorf("credential store not initialized")
+// GetNodeHealth 返回节点的可用率、平均延迟及最近 limit 个心跳样本（limit <= 0 返回全部）
+func (a *App) GetNodeHealth(nodeID string, limit int) (*internal.NodeHealth, error) {
+	if a.healthHistory == nil {
+		return nil, fmt.Errorf("health history not initialized")
 	}
-
-	return a.credStore.DeleteAll(nodeID, username)
+	return a.healthHistory.Health(nodeID, limit), nil
 }
 
-// HasStoredCredential 检查节点是否已存储密码凭据
-// 用于UI显示"记住的密码"状态
-func (a *App) HasStoredCredential(nodeID, username string) bool {
-	if a.credStore == nil || nodeID == "" || username == "" {
-		return false
+// GetHealthSummary 返回所有节点的可用率与平均延迟
+func (a *App) GetHealthSummary() map[string]*internal.NodeHealth {
+	if a.healthHistory == nil {
+		return map[string]*internal.NodeHealth{}
 	}
-
-	return a.credStore.HasPassword(nodeID, username)
+	return a.healthHistory.Summary()
 }
 
-// GetCredential 获取已保存的 SSH 密码明文
-// 注意：仅用于用户显式查看时调用
-func (a *App) GetCredential(nodeID, username string) (string, error) {
-	if a.credStore == nil {
-		return "", fmt.Errorf("credential store not initialized")
+// GetNodeMetrics 返回节点的负载、内存、磁盘与运行时长
+// 缓存未超过采集间隔时直接返回，否则经 SSH 重新采集；采集失败时结果的 Error 字段非空
+func (a *App) GetNodeMetrics(nodeID string) (*internal.NodeMetrics, error) {
+	if a.nodeService == nil || a.telemetry == nil {
+		return nil, fmt.Errorf("services not initialized")
 	}
-	if nodeID == "" || username == "" {
-		return "", fmt.Errorf("invalid credential key")
+	node, err := a.nodeService.GetNode(nodeID)
+	if err != nil {
+		return nil, err
 	}
-	return a.credStore.GetPassword(nodeID, username)
-}
-
-// HasStoredKeyPassphrase 检查节点是否已存储密钥密码短语
-func (a *App) HasStoredKeyPassphrase(nodeID string) bool {
-	if a.credStore == nil || nodeID == "" {
-		return false
+	if node.Protocol.IsFTP() {
+		return nil, fmt.Errorf("telemetry is not supported on FTP nodes")
 	}
 
-	return a.credStore.HasKeyPassphrase(nodeID)
+	maxAge := time.Duration(a.settingsService.Get().Telemetry.IntervalSeconds) * time.Second
+	if maxAge <= 0 {
+		maxAge = settings.DefaultTelemetrySeconds * time.Second
+	}
+	return a.telemetry.Get(node, maxAge), nil
 }
 
-// ===== SVN 资源管理 API =====
+// UpdateTelemetry 更新资源采集间隔与磁盘路径，立即生效
+func (a *App) UpdateTelemetry(cfg internal.Telemetry) error {
+	if a.settingsService == nil || a.telemetry == nil {
+		return fmt.Errorf("services not initialized")
+	}
+	if cfg.IntervalSeconds < 0 {
+		return fmt.Errorf("invalid telemetry interval")
+	}
 
-// GetSVNResources 获取所有 SVN 资源
-func (a *App) GetSVNResources() []*internal.SVNResource {
-	if a.svnService == nil {
-		return []*internal.SVNResource{}
+	current := a.settingsService.Get()
+	current.Telemetry = cfg
+	if err := a.settingsService.Save(current); err != nil {
+		return err
 	}
-	return a.svnService.ListResources()
+	a.telemetry.Start(time.Duration(cfg.IntervalSeconds) * time.Second)
+	return nil
 }
 
-// AddSVNResource 添加 SVN 资源
-func (a *App) AddSVNResource(resource internal.SVNResource) (*internal.SVNResource, error) {
-	if a.svnService == nil {
-		return nil, fmt.Errorf("svn service not initialized")
+// telemetryNodes 返回定期采集资源的节点：仅主控机，从机按需通过 GetNodeMetrics 采集
+func (a *App) telemetryNodes() []*internal.Node {
+	var masters []*internal.Node
+	for _, n := range a.nodeService.ListNodes() {
+		if n.IsMaster && !n.Protocol.IsFTP() {
+			masters = append(masters, n)
+		}
 	}
-	if err := a.svnService.AddResource(&resource); err != nil {
+	return masters
+}
+
+// collectMetrics 经 SSH 执行一次采集脚本并解析结果
+func (a *App) collectMetrics(node *internal.Node) (*internal.NodeMetrics, error) {
+	client, release, err := a.connectSSH(nil, node)
+	if err != nil {
 		return nil, err
 	}
-	return &resource, nil
-}
+	defer release()
 
-// UpdateSVNResource 更新 SVN 资源
-func (a *App) UpdateSVNResource(resource internal.SVNResource) error {
-	if a.svnService == nil {
-		return fmt.Errorf("svn service not initialized")
+	output, err := client.ExecuteCommand(telemetry.Command(a.settingsService.Get().Telemetry.DiskPaths))
+	if err != nil {
+		return nil, i18n.Wrap(err, "telemetry.commandFailed")
 	}
-	return a.svnService.UpdateResource(&resource)
+	metrics, err := telemetry.Parse(output)
+	if errors.Is(err, telemetry.ErrUnsupportedOS) {
+		return nil, i18n.New("telemetry.unsupportedOS")
+	}
+	if err != nil {
+		return nil, i18n.Wrap(err, "telemetry.parseFailed")
+	}
+	return metrics, nil
 }
 
-// DeleteSVNResource 删除 SVN 资源
-func (a *App) DeleteSVNResource(resourceID string) error {
-	if a.svnService == nil {
-		return fmt.Errorf("svn service not initialized")
-	}
-	if a.credStore != nil {
-		if res, err := a.svnService.GetResource(resourceID); err == nil {
-			_ = a.credStore.DeleteSVNPassword(resourceID, res.Username)
+// emitNodeMetrics 推送节点资源采集结果
+func (a *App) emitNodeMetrics(metrics *internal.NodeMetrics) {
+	runtime.EventsEmit(a.ctx, "node:metrics", metrics)
+}
+
+// onNodeStatus 记录节点心跳结果并推送 node:status 事件
+func (a *App) onNodeStatus(nodeID string, status *internal.NodeStatus) {
+	if a.healthHistory != nil {
+		if err := a.healthHistory.Record(nodeID, status); err != nil {
+			log.Printf("Failed to save health history: %v", err)
 		}
 	}
-	return a.svnService.DeleteResource(resourceID)
+	runtime.EventsEmit(a.ctx, "node:status", internal.NodeStatusEvent{NodeID: nodeID, Status: status})
 }
 
-// SaveSVNCredential 保存 SVN 凭据
-func (a *App) SaveSVNCredential(resourceID, username, password string, remember bool) error {
-	if !remember || password == "" {
-		return nil
-	}
-	if a.credStore == nil {
-		return fmt.Errorf("credential store not initialized")
-	}
-	return a.credStore.SetSVNPassword(resourceID, username, password)
+// ===== 远程终端 API =====
+
+// nodeShell 终端会话结束时一并释放 SSH 连接
+type nodeShell struct {
+	*ssh.Shell
+	release func()
 }
 
-// HasStoredSVNCredential 检查 SVN 凭据是否存在
-func (a *App) HasStoredSVNCredential(resourceID, username string) bool {
-	if a.credStore == nil || resourceID == "" || username == "" {
-		return false
-	}
-	return a.credStore.HasSVNPassword(resourceID, username)
+func (s *nodeShell) Close() error {
+	err := s.Shell.Close()
+	s.release()
+	return err
 }
 
-// TestSVNConnection SVN 连接测试（仅检测，不更新资源）
-func (a *App) TestSVNConnection(url, username, password, resourceID string) (*internal.SVNTestResult, error) {
-	if a.svnClient == nil {
-		return nil, fmt.Errorf("svn client not initialized")
+// OpenTerminal 在节点上打开 cols x rows 的交互式终端
+// 输出以 terminal:output 事件推送（base64），会话结束时推送 terminal:closed
+func (a *App) OpenTerminal(nodeID string, cols, rows int) (*internal.TerminalSession, error) {
+	if a.nodeService == nil {
+		return nil, fmt.Errorf("node service not initialized")
 	}
-	if err := a.svnClient.CheckAvailable(); err != nil {
-		_, _ = runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
-			Title:   "SVN 客户端未安装",
-			Message: "未检测到 svn 命令行客户端。请先安装 SVN（如：xcode-select --install 或 brew install svn）。",
-			Type:    runtime.ErrorDialog,
-		})
+	node, err := a.nodeService.GetNode(nodeID)
+	if err != nil {
 		return nil, err
 	}
-	if password == "" && a.credStore != nil && resourceID != "" && username != "" {
-		if stored, err := a.credStore.GetSVNPassword(resourceID, username); err == nil {
-			password = stored
-		}
+	if node.Protocol.IsFTP() {
+		return nil, fmt.Errorf("terminal is not supported on FTP nodes")
+	}
+	if cols <= 0 || rows <= 0 {
+		cols, rows = 80, 24
 	}
 
-	start := time.Now()
-	rev, err := a.svnClient.Info(a.ctx, url, username, password)
-	result := &internal.SVNTestResult{
-		CheckedAt:  time.Now().Format(time.RFC3339),
-		DurationMs: int(time.Since(start).Milliseconds()),
+	client, release, err := a.connectSSH(nil, node)
+	if err != nil {
+		return nil, errors.New(a.i18n.Error(err))
 	}
+	shell, err := client.OpenShell(cols, rows)
 	if err != nil {
-		result.Ok = false
-		result.Message = err.Error()
-		return result, nil
+		release()
+		return nil, errors.New(a.i18n.Error(err))
 	}
-	result.Ok = true
-	result.Revision = rev
-	result.Message = "SVN 连接正常"
-	return result, nil
+	var once sync.Once
+	return a.terminals.Open(node.ID, &nodeShell{Shell: shell, release: func() { once.Do(release) }}), nil
 }
 
-// RefreshSVNResource 刷新资源修订号与状态
-func (a *App) RefreshSVNResource(resourceID string) (*internal.SVNResource, error) {
-	if a.svnService == nil || a.svnClient == nil {
-		return nil, fmt.Errorf("svn service not initialized")
-	}
-	res, err := a.svnService.GetResource(resourceID)
-	if err != nil {
-		return nil, err
-	}
+// WriteTerminal 向终端写入输入（xterm.js onData 的原始字符串）
+func (a *App) WriteTerminal(sessionID, data string) error {
+	return a.terminals.Write(sessionID, []byte(data))
+}
 
-	password := ""
-	if a.credStore != nil && res.Username != "" {
-		if stored, err := a.credStore.GetSVNPassword(resourceID, res.Username); err == nil {
-			password = stored
-		}
-	}
+// ResizeTerminal 调整终端窗口大小
+func (a *App) ResizeTerminal(sessionID string, cols, rows int) error {
+	return a.terminals.Resize(sessionID, cols, rows)
+}
 
-	rev, err := a.svnClient.Info(a.ctx, res.URL, res.Username, password)
+// CloseTerminal 结束终端会话
+func (a *App) CloseTerminal(sessionID string) error {
+	return a.terminals.Close(sessionID)
+}
+
+// ListTerminals 返回进行中的终端会话
+func (a *App) ListTerminals() []*internal.TerminalSession {
+	return a.terminals.List()
+}
+
+func (a *App) emitTerminalOutput(sessionID string, data []byte) {
+	runtime.EventsEmit(a.ctx, "terminal:output", internal.TerminalOutputEvent{
+		SessionID: sessionID,
+		Data:      base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+func (a *App) emitTerminalClosed(sessionID string, err error) {
+	event := internal.TerminalClosedEvent{SessionID: sessionID}
+	if err != nil {
+		event.Error = a.i18n.Error(err)
+	}
+	runtime.EventsEmit(a.ctx, "terminal:closed", event)
+}
+
+// ===== 远程文件 API =====
+
+// openBrowser 建立节点的 SFTP 会话，调用方负责 Close
+func (a *App) openBrowser(nodeID string) (transfer.Browser, error) {
+	if a.nodeService == nil {
+		return nil, fmt.Errorf("node service not initialized")
+	}
+	node, err := a.nodeService.GetNode(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node.Protocol.IsFTP() {
+		return nil, fmt.Errorf("file browsing is not supported on FTP nodes")
+	}
+
+	client, err := a.createSSHClient(node)
+	if err != nil {
+		return nil, errors.New(a.i18n.Error(err))
+	}
+	browser := client.SFTPTransport(node.IP, node.Port)
+	if err := browser.Connect(); err != nil {
+		_ = client.Close()
+		return nil, errors.New(a.i18n.Error(err))
+	}
+	return browser, nil
+}
+
+// checkRemotePath 修改类操作只接受绝对路径，且不允许作用于根目录
+func checkRemotePath(remotePath string) (string, error) {
+	clean := path.Clean(strings.TrimSpace(remotePath))
+	if !path.IsAbs(clean) || clean == "/" {
+		return "", fmt.Errorf("invalid remote path %q", remotePath)
+	}
+	return clean, nil
+}
+
+// ListRemoteDir 列出节点上的目录，dir 为空时为登录用户的主目录
+func (a *App) ListRemoteDir(nodeID, dir string) (*transfer.Listing, error) {
+	browser, err := a.openBrowser(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer browser.Close()
+	return browser.List(dir)
+}
+
+// DownloadRemoteFile 下载节点上的单个文件，localPath 为空时弹出保存对话框
+// 返回保存路径，用户取消时返回空字符串
+func (a *App) DownloadRemoteFile(nodeID, remotePath, localPath string) (string, error) {
+	if strings.TrimSpace(localPath) == "" {
+		var err error
+		localPath, err = runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           a.i18n.T("remote.downloadTitle"),
+			DefaultFilename: path.Base(remotePath),
+		})
+		if err != nil || localPath == "" {
+			return "", err
+		}
+	}
+
+	browser, err := a.openBrowser(nodeID)
+	if err != nil {
+		return "", err
+	}
+	defer browser.Close()
+	if err := browser.Download(remotePath, localPath, nil); err != nil {
+		return "", err
+	}
+	return localPath, nil
+}
+
+// DeleteRemotePath 删除节点上的文件或目录（递归）
+func (a *App) DeleteRemotePath(nodeID, remotePath string) error {
+	target, err := checkRemotePath(remotePath)
+	if err != nil {
+		return err
+	}
+	browser, err := a.openBrowser(nodeID)
+	if err != nil {
+		return err
+	}
+	defer browser.Close()
+	return browser.Remove(target)
+}
+
+// MakeRemoteDir 在节点上递归创建目录
+func (a *App) MakeRemoteDir(nodeID, remotePath string) error {
+	target, err := checkRemotePath(remotePath)
+	if err != nil {
+		return err
+	}
+	browser, err := a.openBrowser(nodeID)
+	if err != nil {
+		return err
+	}
+	defer browser.Close()
+	return browser.MkdirAll(target)
+}
+
+// ===== 拓扑数据 API =====
+
+// GetTopology 获取拓扑结构数据
+func (a *App) GetTopology() *internal.TopologyData {
+	if a.nodeService == nil || a.topologyService == nil {
+		return &internal.TopologyData{
+			Total:  0,
+			Slaves: []*internal.Node{},
+		}
+	}
+
+	nodes := a.nodeService.ListNodes()
+	return a.topologyService.GetTopologyData(nodes)
+}
+
+// GetTopologies 获取所有部署拓扑定义
+func (a *App) GetTopologies() []*internal.Topology {
+	if a.topologyService == nil {
+		return []*internal.Topology{}
+	}
+	return a.topologyService.ListTopologies()
+}
+
+// GetTopologyViews 获取各部署拓扑解析后的节点视图（含选择器匹配的从机）
+func (a *App) GetTopologyViews() []*internal.TopologyData {
+	result := make([]*internal.TopologyData, 0)
+	if a.topologyService == nil || a.nodeService == nil {
+		return result
+	}
+	for _, t := range a.topologyService.ListTopologies() {
+		master, _ := a.nodeService.GetNode(t.MasterID)
+		slaves := make([]*internal.Node, 0)
+		if ids, err := a.selectSlaves(t.SlaveSelector, t.MasterID, t.SlaveIDs); err == nil {
+			for _, id := range ids {
+				if n, err := a.nodeService.GetNode(id); err == nil {
+					slaves = append(slaves, n)
+				}
+			}
+		}
+		result = append(result, a.topologyService.Resolve(t, master, slaves))
+	}
+	return result
+}
+
+// AddTopology 添加部署拓扑
+func (a *App) AddTopology(t internal.Topology) (*internal.Topology, error) {
+	if a.topologyService == nil || a.nodeService == nil {
+		return nil, fmt.Errorf("topology service not initialized")
+	}
+	if err := a.validateTopologyNodes(&t); err != nil {
+		return nil, err
+	}
+	return a.topologyService.AddTopology(&t)
+}
+
+// UpdateTopology 更新部署拓扑
+func (a *App) UpdateTopology(t internal.Topology) error {
+	if a.topologyService == nil || a.nodeService == nil {
+		return fmt.Errorf("topology service not initialized")
+	}
+	if err := a.validateTopologyNodes(&t); err != nil {
+		return err
+	}
+	return a.topologyService.UpdateTopology(&t)
+}
+
+// DeleteTopology 删除部署拓扑，仍被任务或模板引用时拒绝
+func (a *App) DeleteTopology(topologyID string) error {
+	if a.topologyService == nil {
+		return fmt.Errorf("topology service not initialized")
+	}
+	if a.taskService != nil {
+		for _, t := range a.taskService.ListTasks() {
+			if t.TopologyID == topologyID {
+				return fmt.Errorf("%w: %s", topology.ErrTopologyInUse, t.Name)
+			}
+		}
+		for _, t := range a.taskService.ListTemplates() {
+			if t.TopologyID == topologyID {
+				return fmt.Errorf("%w: %s", topology.ErrTopologyInUse, t.Name)
+			}
+		}
+	}
+	return a.topologyService.DeleteTopology(topologyID)
+}
+
+// validateTopologyNodes 校验拓扑引用的节点存在，选择器可解析
+func (a *App) validateTopologyNodes(t *internal.Topology) error {
+	if t.MasterID != "" {
+		if _, err := a.nodeService.GetNode(t.MasterID); err != nil {
+			return fmt.Errorf("master %s: %w", t.MasterID, err)
+		}
+	}
+	for _, id := range t.SlaveIDs {
+		if _, err := a.nodeService.GetNode(id); err != nil {
+			return fmt.Errorf("slave %s: %w", id, err)
+		}
+	}
+	if _, err := node.ParseSelector(t.SlaveSelector); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ===== 凭据管理 API =====
+
+// SaveCredential 保存节点SSH密码凭据
+// rememberPassword: 是否记住密码（存储到系统密钥链）
+func (a *App) SaveCredential(nodeID, username, password string, rememberPassword bool) error {
+	if !rememberPassword {
+		// 用户选择不记住密码，不存储
+		return nil
+	}
+
+	if a.credStore == nil {
+		return fmt.Errorf("credential store not initialized")
+	}
+
+	return a.credStore.SetPassword(nodeID, username, password)
+}
+
+// SaveKeyPassphrase 保存SSH密钥的密码短语
+func (a *App) SaveKeyPassphrase(nodeID, passphrase string, rememberPassphrase bool) error {
+	if !rememberPassphrase || passphrase == "" {
+		// 不记住或密码短语为空
+		return nil
+	}
+
+	if a.credStore == nil {
+		return fmt.Errorf("credential store not initialized")
+	}
+
+	return a.credStore.SetKeyPassphrase(nodeID, passphrase)
+}
+
+// DeleteCredential 删除节点的所有凭据
+// 在删除节点或用户主动清除凭据时调用
+func (a *App) DeleteCredential(nodeID, username string) error {
+	if a.credStore == nil {
+		return fmt.Errorf("credential store not initialized")
+	}
+
+	return a.credStore.DeleteAll(nodeID, username)
+}
+
+// HasStoredCredential 检查节点是否已存储密码凭据
+// 用于UI显示"记住的密码"状态
+func (a *App) HasStoredCredential(nodeID, username string) bool {
+	if a.credStore == nil || nodeID == "" || username == "" {
+		return false
+	}
+
+	return a.credStore.HasPassword(nodeID, username)
+}
+
+// GetCredential 获取已保存的 SSH 密码明文
+// 注意：仅用于用户显式查看时调用
+func (a *App) GetCredential(nodeID, username string) (string, error) {
+	if a.credStore == nil {
+		return "", fmt.Errorf("credential store not initialized")
+	}
+	if nodeID == "" || username == "" {
+		return "", fmt.Errorf("invalid credential key")
+	}
+	return a.credStore.GetPassword(nodeID, username)
+}
+
+// HasStoredKeyPassphrase 检查节点是否已存储密钥密码短语
+func (a *App) HasStoredKeyPassphrase(nodeID string) bool {
+	if a.credStore == nil || nodeID == "" {
+		return false
+	}
+
+	return a.credStore.HasKeyPassphrase(nodeID)
+}
+
+// ===== SVN 资源管理 API =====
+
+// GetSVNResources 获取所有 SVN 资源
+func (a *App) GetSVNResources() []*internal.SVNResource {
+	if a.svnService == nil {
+		return []*internal.SVNResource{}
+	}
+	return a.svnService.ListResources()
+}
+
+// AddSVNResource 添加 SVN 资源
+func (a *App) AddSVNResource(resource internal.SVNResource) (*internal.SVNResource, error) {
+	if a.svnService == nil {
+		return nil, fmt.Errorf("svn service not initialized")
+	}
+	if err := a.svnService.AddResource(&resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// UpdateSVNResource 更新 SVN 资源
+func (a *App) UpdateSVNResource(resource internal.SVNResource) error {
+	if a.svnService == nil {
+		return fmt.Errorf("svn service not initialized")
+	}
+	return a.svnService.UpdateResource(&resource)
+}
+
+// DeleteSVNResource 删除 SVN 资源
+func (a *App) DeleteSVNResource(resourceID string) error {
+	if a.svnService == nil {
+		return fmt.Errorf("svn service not initialized")
+	}
+	if a.credStore != nil {
+		if res, err := a.svnService.GetResource(resourceID); err == nil {
+			_ = a.credStore.DeleteSVNPassword(resourceID, res.Username)
+		}
+	}
+	return a.svnService.DeleteResource(resourceID)
+}
+
+// SaveSVNCredential 保存 SVN 凭据
+func (a *App) SaveSVNCredential(resourceID, username, password string, remember bool) error {
+	if !remember || password == "" {
+		return nil
+	}
+	if a.credStore == nil {
+		return fmt.Errorf("credential store not initialized")
+	}
+	return a.credStore.SetSVNPassword(resourceID, username, password)
+}
+
+// HasStoredSVNCredential 检查 SVN 凭据是否存在
+func (a *App) HasStoredSVNCredential(resourceID, username string) bool {
+	if a.credStore == nil || resourceID == "" || username == "" {
+		return false
+	}
+	return a.credStore.HasSVNPassword(resourceID, username)
+}
+
+// TestSVNConnection SVN 连接测试（仅检测，不更新资源）
+func (a *App) TestSVNConnection(url, username, password, resourceID string) (*internal.SVNTestResult, error) {
+	if a.svnClient == nil {
+		return nil, fmt.Errorf("svn client not initialized")
+	}
+	if err := a.svnClient.CheckAvailable(); err != nil {
+		_, _ = runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+			Title:   a.i18n.T("svn.notInstalledTitle"),
+			Message: a.i18n.T("svn.notInstalledDetail"),
+			Type:    runtime.ErrorDialog,
+		})
+		return nil, err
+	}
+	if password == "" && a.credStore != nil && resourceID != "" && username != "" {
+		if stored, err := a.credStore.GetSVNPassword(resourceID, username); err == nil {
+			password = stored
+		}
+	}
+
+	start := time.Now()
+	rev, err := a.svnClient.Info(a.ctx, url, username, password)
+	result := &internal.SVNTestResult{
+		CheckedAt:  time.Now().Format(time.RFC3339),
+		DurationMs: int(time.Since(start).Milliseconds()),
+	}
+	if err != nil {
+		result.Ok = false
+		result.Message = a.i18n.Error(err)
+		return result, nil
+	}
+	result.Ok = true
+	result.Revision = rev
+	result.Message = a.i18n.T("svn.connectionOk")
+	return result, nil
+}
+
+// RefreshSVNResource 刷新资源修订号与状态
+func (a *App) RefreshSVNResource(resourceID string) (*internal.SVNResource, error) {
+	if a.svnService == nil || a.svnClient == nil {
+		return nil, fmt.Errorf("svn service not initialized")
+	}
+	res, err := a.svnService.GetResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	password := ""
+	if a.credStore != nil && res.Username != "" {
+		if stored, err := a.credStore.GetSVNPassword(resourceID, res.Username); err == nil {
+			password = stored
+		}
+	}
+
+	rev, err := a.svnClient.Info(a.ctx, res.URL, res.Username, password)
 	if err != nil {
 		res.Status = internal.SVNStatusError
 		res.LastChecked = time.Now().Format("2006-01-02 15:04")
@@ -479,12 +1049,186 @@ func (a *App) GetTaskRuns() []*internal.TaskRun {
 	return a.taskService.ListRuns()
 }
 
-// GetTaskRunsByTask 获取指定任务的运行历史
-func (a *App) GetTaskRunsByTask(taskID string) []*internal.TaskRun {
-	if a.taskService == nil {
-		return []*internal.TaskRun{}
+// GetTaskRunsByTask 获取指定任务的运行历史
+func (a *App) GetTaskRunsByTask(taskID string) []*internal.TaskRun {
+	if a.taskService == nil {
+		return []*internal.TaskRun{}
+	}
+	return a.taskService.ListRunsByTask(taskID)
+}
+
+// GetTaskRunLogEntries 获取单次运行的结构化日志
+func (a *App) GetTaskRunLogEntries(runID string) ([]internal.LogEntry, error) {
+	if a.taskService == nil {
+		return nil, fmt.Errorf("task service not initialized")
+	}
+	entries, err := a.taskService.GetRunEntries(runID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i] = a.localizeEntry(entries[i])
+	}
+	return entries, nil
+}
+
+// SearchRuns 检索运行历史
+// NodeID 既可以是节点 ID，也可以是节点名称
+func (a *App) SearchRuns(query internal.RunQuery) (*internal.RunQueryResult, error) {
+	if a.taskService == nil {
+		return nil, fmt.Errorf("task service not initialized")
+	}
+	if query.NodeID != "" && a.nodeService != nil {
+		if _, err := a.nodeService.GetNode(query.NodeID); err != nil {
+			for _, n := range a.nodeService.ListNodes() {
+				if n.Name == query.NodeID {
+					query.NodeID = n.ID
+					break
+				}
+			}
+		}
+	}
+	return a.taskService.SearchRuns(query)
+}
+
+// GetTaskRunLogs 获取单次运行的完整日志，按当前语言渲染
+func (a *App) GetTaskRunLogs(runID string) ([]string, error) {
+	if a.taskService == nil {
+		return nil, fmt.Errorf("task service not initialized")
+	}
+	return a.localizedRunLogs(runID)
+}
+
+// localizedRunLogs 读取运行日志并按当前语言渲染为展示文本
+func (a *App) localizedRunLogs(runID string) ([]string, error) {
+	entries, err := a.taskService.GetRunEntries(runID)
+	if err != nil {
+		return nil, err
+	}
+	return a.localizedLogLines(entries), nil
+}
+
+// localizedLogLines 将结构化日志按当前语言渲染为展示文本
+func (a *App) localizedLogLines(entries []internal.LogEntry) []string {
+	logs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, a.formatLogEntry(a.localizeEntry(entry)))
+	}
+	return logs
+}
+
+// localizeEntry 带消息键的日志按当前语言重新渲染，旧日志原样返回
+func (a *App) localizeEntry(entry internal.LogEntry) internal.LogEntry {
+	if entry.Key == "" {
+		return entry
+	}
+	args := make([]any, len(entry.Args))
+	for i, arg := range entry.Args {
+		args[i] = arg
+	}
+	entry.Message = a.i18n.T(entry.Key, args...)
+	return entry
+}
+
+// formatLogEntry 返回日志的展示文本，级别前缀按当前语言渲染
+func (a *App) formatLogEntry(entry internal.LogEntry) string {
+	text := a.i18n.T("log.prefix."+string(entry.Level)) + entry.Message
+	if entry.Time == "" {
+		return text
+	}
+	return "[" + entry.Time + "] " + text
+}
+
+// GetDeploymentStats 获取部署统计
+// days 为统计最近天数（含今天），<= 0 表示全部历史
+func (a *App) GetDeploymentStats(days int) (*internal.DeploymentStats, error) {
+	if a.taskService == nil {
+		return nil, fmt.Errorf("task service not initialized")
+	}
+	var since time.Time
+	if days > 0 {
+		now := time.Now()
+		since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -(days - 1))
+	}
+
+	stats := a.taskService.Stats(since)
+	if a.nodeService != nil {
+		for i, item := range stats.FailingNodes {
+			if n, err := a.nodeService.GetNode(item.Key); err == nil {
+				stats.FailingNodes[i].Label = n.Name
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ExportRunReport 导出单次运行的执行报告
+// format: html | markdown | json；path 为空时弹出保存对话框，取消时返回空路径
+func (a *App) ExportRunReport(runID, format, path string) (string, error) {
+	if a.taskService == nil {
+		return "", fmt.Errorf("task service not initialized")
+	}
+	f, err := report.ParseFormat(format)
+	if err != nil {
+		return "", err
+	}
+	run, err := a.taskService.GetRun(runID)
+	if err != nil {
+		return "", err
+	}
+	entries, err := a.taskService.GetRunEntries(runID)
+	if err != nil {
+		return "", err
+	}
+	// 步骤完整输出存于运行日志，回填到时间线而不计入日志部分
+	outputs, entries := task.StepOutputs(entries)
+	if len(outputs) > 0 {
+		copied := *run
+		copied.Steps = append([]internal.RunStep{}, run.Steps...)
+		for i, output := range outputs {
+			if i < len(copied.Steps) {
+				copied.Steps[i].Output = output
+			}
+		}
+		run = &copied
+	}
+	logs := a.localizedLogLines(entries)
+
+	def, _ := a.taskService.GetTask(run.TaskID)
+	var resource *internal.SVNResource
+	if def != nil && a.svnService != nil {
+		resource, _ = a.svnService.GetResource(def.SVNResourceID)
+	}
+	nodes := make([]*internal.Node, 0, len(run.NodeIDs))
+	if a.nodeService != nil {
+		for _, id := range run.NodeIDs {
+			if n, err := a.nodeService.GetNode(id); err == nil {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	data, err := report.Render(report.New(run, def, resource, nodes, logs, a.i18n.Lang()), f)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(path) == "" {
+		path, err = runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           a.i18n.T("report.exportTitle"),
+			DefaultFilename: "report-" + run.ID + f.Ext(),
+			Filters: []runtime.FileFilter{
+				{DisplayName: a.i18n.T("report.fileFilter", f.Ext()), Pattern: "*" + f.Ext()},
+			},
+		})
+		if err != nil || path == "" {
+			return "", err
+		}
 	}
-	return a.taskService.ListRunsByTask(taskID)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
 }
 
 // DeleteTaskRun 删除运行记录
@@ -503,6 +1247,94 @@ func (a *App) DeleteTaskRunsByTask(taskID string) error {
 	return a.taskService.DeleteRunsByTask(taskID)
 }
 
+// ===== 应用设置 API =====
+
+// GetSettings 获取应用设置
+func (a *App) GetSettings() internal.AppSettings {
+	if a.settingsService == nil {
+		return settings.Defaults()
+	}
+	return a.settingsService.Get()
+}
+
+// UpdateRunRetention 更新运行历史保留策略并立即清理
+func (a *App) UpdateRunRetention(retention internal.RunRetention) error {
+	if a.settingsService == nil || a.taskService == nil {
+		return fmt.Errorf("services not initialized")
+	}
+	if retention.MaxRunsPerTask < 0 || retention.MaxAgeDays < 0 {
+		return fmt.Errorf("invalid retention policy")
+	}
+	if retention.MaxRunsPerTask > 0 && retention.MaxRunsPerTask < settings.MinRunsPerTask {
+		return fmt.Errorf("maxRunsPerTask must be 0 or at least %d", settings.MinRunsPerTask)
+	}
+
+	current := a.settingsService.Get()
+	current.RunRetention = retention
+	if err := a.settingsService.Save(current); err != nil {
+		return err
+	}
+	return a.taskService.SetRetention(retention)
+}
+
+// SetStorageBackend 设置数据存储后端（json | sqlite），重启应用后生效
+func (a *App) SetStorageBackend(backend string) error {
+	if a.settingsService == nil {
+		return fmt.Errorf("settings service not initialized")
+	}
+	value := internal.StorageBackend(strings.ToLower(strings.TrimSpace(backend)))
+	if value != internal.StorageJSON && value != internal.StorageSQLite {
+		return fmt.Errorf("unsupported storage backend: %s", backend)
+	}
+
+	current := a.settingsService.Get()
+	current.StorageBackend = value
+	return a.settingsService.Save(current)
+}
+
+// SetLanguage 设置日志与错误信息语言（zh-CN | en-US），立即生效
+// 已持久化的结构化日志在读取时按新语言重新渲染
+func (a *App) SetLanguage(lang string) error {
+	if a.settingsService == nil {
+		return fmt.Errorf("settings service not initialized")
+	}
+	value := i18n.Normalize(lang)
+	current := a.settingsService.Get()
+	current.Language = string(value)
+	if err := a.settingsService.Save(current); err != nil {
+		return err
+	}
+	a.i18n.SetLang(value)
+	return nil
+}
+
+// UpdateSSHKeepAlive 更新 SSH 保活与空闲检测设置，对之后建立的连接生效
+func (a *App) UpdateSSHKeepAlive(keepAlive internal.SSHKeepAlive) error {
+	if a.settingsService == nil {
+		return fmt.Errorf("settings service not initialized")
+	}
+	if keepAlive.IntervalSeconds < 0 || keepAlive.MaxMissed < 0 || keepAlive.IdleTimeoutSeconds < 0 {
+		return fmt.Errorf("invalid keepalive settings")
+	}
+
+	current := a.settingsService.Get()
+	current.SSHKeepAlive = keepAlive
+	return a.settingsService.Save(current)
+}
+
+// sshKeepAlive 返回当前设置对应的连接保活配置
+func (a *App) sshKeepAlive() ssh.KeepAlive {
+	cfg := settings.Defaults().SSHKeepAlive
+	if a.settingsService != nil {
+		cfg = a.settingsService.Get().SSHKeepAlive
+	}
+	return ssh.KeepAlive{
+		Interval:    time.Duration(cfg.IntervalSeconds) * time.Second,
+		MaxMissed:   cfg.MaxMissed,
+		IdleTimeout: time.Duration(cfg.IdleTimeoutSeconds) * time.Second,
+	}
+}
+
 // CheckoutSVNResource 导出 SVN 资源到本地目录
 // targetDir 为空时默认存储到 dataDir/svn-cache/<resourceID>
 func (a *App) CheckoutSVNResource(resourceID, targetDir string) (string, error) {
@@ -520,6 +1352,14 @@ func (a *App) CheckoutSVNResource(resourceID, targetDir string) (string, error)
 
 	if targetDir == "" {
 		targetDir = filepath.Join(a.dataDir, "svn-cache", resourceID)
+		// 与任务运行共用导出缓存目录，检出期间持有缓存锁，避免并发写入
+		if a.runLocker != nil {
+			lease, err := a.runLocker.Acquire("", res.Name, []string{task.RunLockKeyCache(resourceID)}, internal.RunConcurrencyReject)
+			if err != nil {
+				return "", err
+			}
+			defer lease.Release()
+		}
 	}
 
 	password := ""
@@ -579,6 +1419,7 @@ func (a *App) CheckoutSVNResource(resourceID, targetDir string) (string, error)
 
 // ExecuteTask 执行任务流水线（下载->上传->同步->执行）
 // 通过事件推送任务进度与日志：task:event
+// 同一任务或目标路径已有运行时按 req.Concurrency 拒绝、排队或放行
 func (a *App) ExecuteTask(req internal.TaskRunRequest) error {
 	if a.svnService == nil || a.svnClient == nil || a.nodeService == nil {
 		return fmt.Errorf("services not initialized")
@@ -586,197 +1427,860 @@ func (a *App) ExecuteTask(req internal.TaskRunRequest) error {
 	if req.TaskID == "" {
 		return fmt.Errorf("taskId is required")
 	}
+	req, err := a.resolveTargets(req)
+	if err != nil {
+		return err
+	}
 
-	go a.runTask(req)
+	lease, err := a.acquireRunLock(req)
+	if err != nil {
+		return err
+	}
+	runID := a.createRun(req, "")
+	lease.Bind(runID)
+	go a.runTask(req, runID, nil, lease)
 	return nil
 }
 
-func (a *App) runTask(req internal.TaskRunRequest) {
+// RetryRun 从失败阶段重试运行
+// 复用原运行的本地导出缓存；若主控机上传已完成且校验和一致，则从同步或命令执行阶段继续
+// 返回新运行 ID，新运行通过 retryOf 关联原运行
+func (a *App) RetryRun(runID string) (string, error) {
+	if a.svnService == nil || a.svnClient == nil || a.nodeService == nil || a.taskService == nil {
+		return "", fmt.Errorf("services not initialized")
+	}
+
+	run, err := a.taskService.GetRun(runID)
+	if err != nil {
+		return "", err
+	}
+	if run.Status != internal.TaskStatusFailed {
+		return "", fmt.Errorf("only failed runs can be retried")
+	}
+
+	// 优先按原运行的配置快照重试；旧运行无快照时取当前任务定义
+	var req internal.TaskRunRequest
+	if run.Snapshot != nil {
+		req = run.Snapshot.Request
+	} else {
+		def, err := a.taskService.GetTask(run.TaskID)
+		if err != nil {
+			return "", err
+		}
+		if req, err = a.resolveTargets(taskRunRequestFromDefinition(def)); err != nil {
+			return "", err
+		}
+	}
+
+	lease, err := a.acquireRunLock(req)
+	if err != nil {
+		return "", err
+	}
+	newRunID := a.createRun(req, run.ID)
+	lease.Bind(newRunID)
+	go a.runTask(req, newRunID, run, lease)
+	return newRunID, nil
+}
+
+// RerunExact 按历史运行的配置快照重新执行，并固定为该运行实际部署的修订号
+// 节点连接信息与凭据取当前配置；返回新运行 ID
+func (a *App) RerunExact(runID string) (string, error) {
+	if a.svnService == nil || a.svnClient == nil || a.nodeService == nil || a.taskService == nil {
+		return "", fmt.Errorf("services not initialized")
+	}
+
+	run, err := a.taskService.GetRun(runID)
+	if err != nil {
+		return "", err
+	}
+	if run.Snapshot == nil {
+		return "", fmt.Errorf("run has no configuration snapshot")
+	}
+
+	req := run.Snapshot.Request
+	req.Concurrency = ""
+	if run.SVNRevision != "" {
+		req.SVNRevision = run.SVNRevision
+	} else if run.Snapshot.Revision != "" {
+		req.SVNRevision = run.Snapshot.Revision
+	}
+
+	lease, err := a.acquireRunLock(req)
+	if err != nil {
+		return "", err
+	}
+	newRunID := a.createRun(req, "")
+	lease.Bind(newRunID)
+	go a.runTask(req, newRunID, nil, lease)
+	return newRunID, nil
+}
+
+// resolveTargets 解析运行目标：引用拓扑时主控机与从机取自拓扑，
+// 任务自身的显式从机与选择器在其基础上追加（去重，排除主控节点）
+// 解析结果写入运行快照，重试与按快照重新执行沿用同一批节点
+func (a *App) resolveTargets(req internal.TaskRunRequest) (internal.TaskRunRequest, error) {
+	if req.TopologyID != "" {
+		if a.topologyService == nil {
+			return req, fmt.Errorf("topology service not initialized")
+		}
+		t, err := a.topologyService.GetTopology(req.TopologyID)
+		if err != nil {
+			return req, err
+		}
+		ids, err := a.selectSlaves(t.SlaveSelector, t.MasterID, t.SlaveIDs)
+		if err != nil {
+			return req, err
+		}
+		req.MasterServerID = t.MasterID
+		req.SlaveServerIDs = append(ids, req.SlaveServerIDs...)
+	}
+
+	ids, err := a.selectSlaves(req.SlaveSelector, req.MasterServerID, req.SlaveServerIDs)
+	if err != nil {
+		return req, err
+	}
+	req.SlaveServerIDs = ids
+	return req, nil
+}
+
+// selectSlaves 将选择器匹配的节点追加到显式从机列表，去重并排除主控节点
+// 选择器非空但未匹配任何从机时返回 ErrNoMatchingNodes
+func (a *App) selectSlaves(selector, masterID string, explicit []string) ([]string, error) {
+	ids := make([]string, 0, len(explicit))
+	seen := map[string]bool{masterID: true}
+	for _, id := range explicit {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if strings.TrimSpace(selector) == "" {
+		return ids, nil
+	}
+
+	nodes, err := a.nodeService.SelectNodes(selector)
+	if err != nil {
+		return nil, err
+	}
+	matched := 0
+	for _, n := range nodes {
+		if n.ID == masterID {
+			continue
+		}
+		matched++
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			ids = append(ids, n.ID)
+		}
+	}
+	if matched == 0 {
+		return nil, fmt.Errorf("%w: %s", node.ErrNoMatchingNodes, selector)
+	}
+	return ids, nil
+}
+
+// GetActiveRuns 获取运行中与排队中的运行锁状态
+func (a *App) GetActiveRuns() []*internal.RunLockState {
+	if a.runLocker == nil {
+		return []*internal.RunLockState{}
+	}
+	return a.runLocker.Active()
+}
+
+// acquireRunLock 按请求的并发策略申请运行锁
+func (a *App) acquireRunLock(req internal.TaskRunRequest) (*task.RunLease, error) {
 	taskName := req.TaskName
 	if taskName == "" && a.taskService != nil {
-		if task, err := a.taskService.GetTask(req.TaskID); err == nil {
-			taskName = task.Name
+		if def, err := a.taskService.GetTask(req.TaskID); err == nil {
+			taskName = def.Name
+		}
+	}
+	return a.runLocker.Acquire(req.TaskID, taskName, runLockKeys(req), req.Concurrency)
+}
+
+// runLockKeys 计算运行需要锁定的资源键：任务、导出缓存目录、各节点目标路径
+func runLockKeys(req internal.TaskRunRequest) []string {
+	keys := []string{
+		task.RunLockKeyTask(req.TaskID),
+		task.RunLockKeyCache(req.SVNResourceID),
+	}
+
+	masterPath := req.RemotePath
+	if strings.TrimSpace(masterPath) == "" {
+		masterPath = "/tmp/deploymaster"
+	}
+	// 客户端直传不向主控机写入文件
+	if req.TransferMode != internal.TransferDirect {
+		keys = append(keys, task.RunLockKeyNodePath(req.MasterServerID, filepath.ToSlash(masterPath)))
+	}
+
+	slaveBase := req.SlaveRemotePath
+	if strings.TrimSpace(slaveBase) == "" {
+		slaveBase = masterPath
+	}
+	for _, slaveID := range req.SlaveServerIDs {
+		slavePath := slaveBase
+		if custom, ok := req.SlaveRemotePaths[slaveID]; ok && strings.TrimSpace(custom) != "" {
+			slavePath = custom
+		}
+		keys = append(keys, task.RunLockKeyNodePath(slaveID, filepath.ToSlash(slavePath)))
+	}
+	return keys
+}
+
+// taskRunRequestFromDefinition 由任务定义构造执行请求
+func taskRunRequestFromDefinition(task *internal.TaskDefinition) internal.TaskRunRequest {
+	return internal.TaskRunRequest{
+		TaskID:           task.ID,
+		TaskName:         task.Name,
+		SVNResourceID:    task.SVNResourceID,
+		MasterServerID:   task.MasterServerID,
+		TopologyID:       task.TopologyID,
+		SlaveServerIDs:   task.SlaveServerIDs,
+		SlaveSelector:    task.SlaveSelector,
+		RemotePath:       task.RemotePath,
+		SlaveRemotePath:  task.SlaveRemotePath,
+		SlaveRemotePaths: task.SlaveRemotePaths,
+		TransferMode:     task.TransferMode,
+		Commands:         task.Commands,
+	}
+}
+
+// createRun 创建运行记录，失败时返回空 ID（不阻断执行）
+func (a *App) createRun(req internal.TaskRunRequest, retryOf string) string {
+	if a.taskService == nil {
+		return ""
+	}
+
+	taskName := req.TaskName
+	if taskName == "" {
+		if def, err := a.taskService.GetTask(req.TaskID); err == nil {
+			taskName = def.Name
 		}
 	}
 	if taskName == "" {
 		taskName = req.TaskID
 	}
 
-	runID := ""
-	if a.taskService != nil {
-		if run, err := a.taskService.CreateRun(req.TaskID, taskName); err == nil && run != nil {
-			runID = run.ID
+	run, err := a.taskService.CreateRetryRun(req.TaskID, taskName, retryOf)
+	if err != nil || run == nil {
+		return ""
+	}
+
+	nodeIDs := make([]string, 0, len(req.SlaveServerIDs)+1)
+	if req.MasterServerID != "" {
+		nodeIDs = append(nodeIDs, req.MasterServerID)
+	}
+	nodeIDs = append(nodeIDs, req.SlaveServerIDs...)
+	_ = a.taskService.SetRunTargets(run.ID, nodeIDs)
+	_ = a.taskService.SetRunSnapshot(run.ID, a.buildRunSnapshot(req))
+	return run.ID
+}
+
+// buildRunSnapshot 解析运行请求引用的资源与节点，生成配置快照
+func (a *App) buildRunSnapshot(req internal.TaskRunRequest) *internal.RunSnapshot {
+	snapshot := &internal.RunSnapshot{
+		Request:   req,
+		Revision:  req.SVNRevision,
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	// 深拷贝引用类型，避免后续修改任务定义影响快照
+	snapshot.Request.SlaveServerIDs = append([]string{}, req.SlaveServerIDs...)
+	snapshot.Request.Commands = append([]string{}, req.Commands...)
+	if req.SlaveRemotePaths != nil {
+		snapshot.Request.SlaveRemotePaths = make(map[string]string, len(req.SlaveRemotePaths))
+		for k, v := range req.SlaveRemotePaths {
+			snapshot.Request.SlaveRemotePaths[k] = v
+		}
+	}
+
+	if a.svnService != nil {
+		if resource, err := a.svnService.GetResource(req.SVNResourceID); err == nil {
+			snapshot.ResourceURL = resource.URL
+			snapshot.ResourceType = resource.Type
+		}
+	}
+	if a.nodeService != nil {
+		if master, err := a.nodeService.GetNode(req.MasterServerID); err == nil {
+			n := *master
+			n.Status = nil
+			snapshot.Master = &n
+		}
+		for _, id := range req.SlaveServerIDs {
+			if slave, err := a.nodeService.GetNode(id); err == nil {
+				n := *slave
+				n.Status = nil
+				snapshot.Slaves = append(snapshot.Slaves, &n)
+			}
+		}
+	}
+	return snapshot
+}
+
+// runTask 执行流水线
+// retryOf 非空时按其断点信息跳过已完成且校验一致的阶段；结束后释放运行锁
+func (a *App) runTask(req internal.TaskRunRequest, runID string, retryOf *internal.TaskRun, lease *task.RunLease) {
+	defer lease.Release()
+
+	phase := internal.RunPhaseExport
+
+	// emitEntry 发送结构化日志事件并持久化；未指定阶段时取当前阶段
+	emitEntry := func(status internal.TaskStatus, progress int, entry internal.LogEntry) {
+		entry.Time = time.Now().Format("2006-01-02 15:04:05")
+		if entry.Phase == "" {
+			entry.Phase = phase
+		}
+		runtime.EventsEmit(a.ctx, "task:event", internal.TaskEvent{
+			TaskID:   req.TaskID,
+			RunID:    runID,
+			Status:   status,
+			Progress: progress,
+			Log:      a.formatLogEntry(entry),
+			Entry:    &entry,
+		})
+		if a.taskService != nil {
+			_ = a.taskService.UpdateTaskState(req.TaskID, status, progress)
+			if runID != "" {
+				_ = a.taskService.AppendRunEntry(runID, status, progress, entry)
+			}
+		}
+	}
+	// emitMsg 以消息键发送日志，按当前语言渲染并保留键与参数以便切换语言后重新渲染
+	emitMsg := func(status internal.TaskStatus, progress int, entry internal.LogEntry, msg *i18n.Message) {
+		entry.Message = a.i18n.Render(msg)
+		entry.Key = msg.Key
+		entry.Args = msg.Strings(a.i18n.Lang())
+		emitEntry(status, progress, entry)
+	}
+	emit := func(status internal.TaskStatus, progress int, level internal.LogLevel, key string, args ...any) {
+		emitMsg(status, progress, internal.LogEntry{Level: level}, i18n.New(key, args...))
+	}
+	checkpoint := &internal.RunCheckpoint{}
+	saveCheckpoint := func(done internal.RunPhase) {
+		checkpoint.CompletedPhases = append(checkpoint.CompletedPhases, done)
+		if a.taskService != nil && runID != "" {
+			_ = a.taskService.SetRunCheckpoint(runID, checkpoint)
+		}
+	}
+
+	// 执行时间线：beginStep 标记步骤开始，endStep 或 fail 写入结果
+	var stepNode, stepName string
+	var stepStart time.Time
+	recordStep := func(nodeID, name string, started time.Time, status internal.RunStepStatus, output, errMsg string) {
+		if a.taskService == nil || runID == "" {
+			return
+		}
+		finished := time.Now()
+		_ = a.taskService.AddRunStep(runID, internal.RunStep{
+			Phase:      phase,
+			NodeID:     nodeID,
+			Name:       name,
+			StartedAt:  started.Format("2006-01-02 15:04:05"),
+			FinishedAt: finished.Format("2006-01-02 15:04:05"),
+			DurationMs: finished.Sub(started).Milliseconds(),
+			Status:     status,
+			Output:     output,
+			Error:      errMsg,
+		})
+	}
+	beginStep := func(nodeID, name string) {
+		stepNode, stepName, stepStart = nodeID, name, time.Now()
+	}
+	endStep := func(status internal.RunStepStatus, output, errMsg string) {
+		if stepName == "" {
+			return
+		}
+		recordStep(stepNode, stepName, stepStart, status, output, errMsg)
+		stepName = ""
+	}
+
+	fail := func(progress int, key string, args ...any) {
+		nodeID := ""
+		if stepName != "" {
+			nodeID = stepNode
+		}
+		msg := i18n.New(key, args...)
+		reason := a.i18n.Render(msg)
+		if a.taskService != nil && runID != "" {
+			_ = a.taskService.SetRunFailure(runID, phase, key, reason)
+		}
+		endStep(internal.RunStepFailed, "", reason)
+		emitMsg(internal.TaskStatusFailed, progress, internal.LogEntry{Level: internal.LogLevelError, NodeID: nodeID}, msg)
+	}
+
+	if lease.Queued() {
+		emit(internal.TaskStatusIdle, 0, internal.LogLevelInfo, "pipeline.queued")
+		lease.Wait()
+		emit(internal.TaskStatusIdle, 0, internal.LogLevelInfo, "pipeline.dequeued")
+	}
+
+	// 本次运行内各阶段复用到同一节点的 SSH 连接
+	pool := ssh.NewPool()
+	defer pool.Close()
+
+	var prev *internal.RunCheckpoint
+	if retryOf != nil {
+		prev = retryOf.Checkpoint
+		emit(internal.TaskStatusDownloading, 5, internal.LogLevelInfo, "pipeline.retry", retryOf.ID, retryOf.FailedPhase)
+	} else {
+		emit(internal.TaskStatusDownloading, 5, internal.LogLevelInfo, "pipeline.start")
+	}
+
+	beginStep("", a.i18n.T("step.export"))
+	resource, err := a.svnService.GetResource(req.SVNResourceID)
+	if err != nil {
+		fail(5, "export.resourceNotFound")
+		return
+	}
+	isFile := resource.Type == internal.SVNResourceFile
+
+	cacheDir := filepath.Join(a.dataDir, "svn-cache", req.SVNResourceID)
+	exportDest := cacheDir
+	baseName := path.Base(strings.TrimRight(resource.URL, "/"))
+	if isFile {
+		if baseName == "" || baseName == "." || baseName == "/" {
+			baseName = "package.bin"
+		}
+		exportDest = filepath.Join(cacheDir, baseName)
+	} else if baseName != "" && baseName != "." && baseName != "/" {
+		exportDest = filepath.Join(cacheDir, baseName)
+	}
+
+	reuseExport, cacheChanged := canReuseExport(prev, exportDest, req.SVNRevision, func() (string, error) {
+		return checksum.Local(exportDest)
+	})
+	if cacheChanged {
+		emit(internal.TaskStatusDownloading, 10, internal.LogLevelWarn, "export.cacheChanged")
+	}
+
+	if reuseExport {
+		checkpoint.ExportPath = prev.ExportPath
+		checkpoint.Revision = prev.Revision
+		checkpoint.Checksum = prev.Checksum
+		emitMsg(internal.TaskStatusDownloading, 30, internal.LogEntry{
+			Level:  internal.LogLevelInfo,
+			Fields: map[string]string{"revision": prev.Revision, "checksum": prev.Checksum},
+		}, i18n.New("export.reuse", prev.Revision, exportDest))
+	} else {
+		if req.SVNRevision != "" {
+			emit(internal.TaskStatusDownloading, 15, internal.LogLevelInfo, "export.connectRevision", req.SVNRevision)
+		} else {
+			emit(internal.TaskStatusDownloading, 15, internal.LogLevelInfo, "export.connectHead")
+		}
+
+		password := ""
+		if a.credStore != nil && resource.Username != "" {
+			if stored, err := a.credStore.GetSVNPassword(req.SVNResourceID, resource.Username); err == nil {
+				password = stored
+			}
+		}
+
+		if err := os.MkdirAll(cacheDir, 0755); err != nil {
+			fail(15, "export.mkdirFailed", err)
+			return
+		}
+
+		// 固定修订号，保证重试与按快照重新执行时导出内容一致
+		revision := req.SVNRevision
+		if revision == "" && prev != nil {
+			revision = prev.Revision
+		}
+		if revision == "" {
+			if rev, err := a.svnClient.Info(a.ctx, resource.URL, resource.Username, password); err == nil {
+				revision = rev
+			}
+		}
+
+		if isFile {
+			if err := a.svnClient.CatToFile(a.ctx, resource.URL, resource.Username, password, revision, exportDest); err != nil {
+				fail(15, "export.checkoutFailed", err)
+				return
+			}
+		} else if err := a.svnClient.Export(a.ctx, resource.URL, resource.Username, password, revision, exportDest); err != nil {
+			fail(15, "export.checkoutFailed", err)
+			return
+		}
+
+		sum, err := checksum.Local(exportDest)
+		if err != nil {
+			fail(30, "export.checksumFailed", err)
+			return
+		}
+		checkpoint.ExportPath = exportDest
+		checkpoint.Revision = revision
+		checkpoint.Checksum = sum
+		emitMsg(internal.TaskStatusDownloading, 30, internal.LogEntry{
+			Level:  internal.LogLevelInfo,
+			Fields: map[string]string{"revision": revision, "checksum": sum},
+		}, i18n.New("export.done", exportDest))
+	}
+	exportStatus := internal.RunStepSuccess
+	if reuseExport {
+		exportStatus = internal.RunStepSkipped
+	}
+	endStep(exportStatus, fmt.Sprintf("%s@%s -> %s\nsha256 %s", resource.URL, checkpoint.Revision, exportDest, checkpoint.Checksum), "")
+	saveCheckpoint(internal.RunPhaseExport)
+	if a.taskService != nil && runID != "" && checkpoint.Revision != "" {
+		_ = a.taskService.SetRunRevision(runID, checkpoint.Revision)
+	}
+
+	syncTargets := make(map[string]string, len(req.SlaveServerIDs))
+	for _, id := range req.SlaveServerIDs {
+		syncTargets[id] = slaveDestination(req, id, isFile, baseName)
+	}
+	if req.TransferMode == internal.TransferDirect {
+		// 客户端直传不经主控机：跳过主控上传，主控机仅在需要执行命令时使用
+		// 各从机上传记录为同步阶段的节点步骤，不再记录整体同步步骤，避免统计时重复累计耗时
+		phase = internal.RunPhaseSync
+		if canSkipSync(prev, reuseExport, syncTargets) {
+			emit(internal.TaskStatusSyncing, 77, internal.LogLevelInfo, "sync.skip")
+			recordStep("", a.i18n.T("step.sync"), time.Now(), internal.RunStepSkipped, "", "")
+		} else {
+			total := len(req.SlaveServerIDs)
+			emit(internal.TaskStatusSyncing, 65, internal.LogLevelInfo, "sync.directStart", total, directUploadConcurrency)
+			done := 0
+			onUpload := func(node *internal.Node, started time.Time, dest string, err error) {
+				done++
+				progress := 65 + 12*done/total
+				fields := map[string]string{"durationMs": fmt.Sprint(time.Since(started).Milliseconds()), "path": dest}
+				if err != nil {
+					recordStep(node.ID, a.i18n.T("step.directUpload"), started, internal.RunStepFailed, dest, a.i18n.Error(err))
+					emitMsg(internal.TaskStatusSyncing, progress, internal.LogEntry{Level: internal.LogLevelError, NodeID: node.ID, Fields: fields}, i18n.New("sync.directNodeFailed", node.Name, err))
+					return
+				}
+				recordStep(node.ID, a.i18n.T("step.directUpload"), started, internal.RunStepSuccess, dest, "")
+				emitMsg(internal.TaskStatusSyncing, progress, internal.LogEntry{Level: internal.LogLevelInfo, NodeID: node.ID, Fields: fields}, i18n.New("sync.directNodeDone", node.Name, dest))
+			}
+			if err := a.uploadToSlaves(pool, req, exportDest, isFile, baseName, onUpload); err != nil {
+				fail(77, "sync.directFailed", err)
+				return
+			}
+			emit(internal.TaskStatusSyncing, 77, internal.LogLevelInfo, "sync.directDone")
+		}
+	} else {
+		phase = internal.RunPhaseUpload
+		beginStep(req.MasterServerID, a.i18n.T("step.upload"))
+		master, err := a.nodeService.GetNode(req.MasterServerID)
+		if err != nil {
+			fail(30, "upload.masterNotFound")
+			return
+		}
+
+		remoteTarget := req.RemotePath
+		if strings.TrimSpace(remoteTarget) == "" {
+			remoteTarget = "/tmp/deploymaster"
+		}
+		if isFile {
+			remoteTarget = filepath.ToSlash(filepath.Join(remoteTarget, baseName))
+		}
+
+		reuseUpload, recheck := canReuseUpload(prev, reuseExport, master.ID, remoteTarget, func() (bool, error) {
+			return a.verifyRemoteChecksum(pool, master, remoteTarget, !isFile, checkpoint.Checksum)
+		})
+		if recheck {
+			emit(internal.TaskStatusUploading, 40, internal.LogLevelWarn, "upload.recheck")
+		}
+
+		if reuseUpload {
+			emitMsg(internal.TaskStatusUploading, 55, internal.LogEntry{Level: internal.LogLevelInfo, NodeID: master.ID}, i18n.New("upload.skip", remoteTarget))
+		} else {
+			emitMsg(internal.TaskStatusUploading, 45, internal.LogEntry{Level: internal.LogLevelInfo, NodeID: master.ID}, i18n.New("upload.start", master.Protocol, remoteTarget))
+			// 上传进度映射到 45-55，每完成四分之一记录一次日志
+			reported := 0
+			onProgress := func(written, total int64) {
+				if total <= 0 {
+					return
+				}
+				quarter := int(written * 4 / total)
+				if quarter <= reported || quarter >= 4 {
+					return
+				}
+				reported = quarter
+				emitMsg(internal.TaskStatusUploading, 45+int(written*10/total), internal.LogEntry{Level: internal.LogLevelInfo, NodeID: master.ID},
+					i18n.New("upload.progress", formatBytes(written), formatBytes(total)))
+			}
+			if err := a.uploadToNode(pool, master, exportDest, remoteTarget, onProgress); err != nil {
+				fail(45, "upload.failed", err)
+				return
+			}
+			emitMsg(internal.TaskStatusUploading, 55, internal.LogEntry{Level: internal.LogLevelInfo, NodeID: master.ID}, i18n.New("upload.done", remoteTarget))
+		}
+		checkpoint.MasterID = master.ID
+		checkpoint.MasterPath = remoteTarget
+		uploadStatus := internal.RunStepSuccess
+		if reuseUpload {
+			uploadStatus = internal.RunStepSkipped
+		}
+		endStep(uploadStatus, fmt.Sprintf("%s -> %s:%s", exportDest, master.IP, remoteTarget), "")
+		saveCheckpoint(internal.RunPhaseUpload)
+
+		phase = internal.RunPhaseSync
+		beginStep(master.ID, a.i18n.T("step.sync"))
+		// 从机或目标路径与上次不同时需要重新同步
+		if canSkipSync(prev, reuseUpload, syncTargets) {
+			emit(internal.TaskStatusSyncing, 77, internal.LogLevelInfo, "sync.skip")
+			endStep(internal.RunStepSkipped, "", "")
+		} else {
+			slaveTargetBase := req.SlaveRemotePath
+			if strings.TrimSpace(slaveTargetBase) == "" {
+				slaveTargetBase = req.RemotePath
+			}
+			if strings.TrimSpace(slaveTargetBase) == "" {
+				slaveTargetBase = "/tmp/deploymaster"
+			}
+
+			emit(internal.TaskStatusSyncing, 65, internal.LogLevelInfo, "sync.start", len(req.SlaveServerIDs))
+			emit(internal.TaskStatusSyncing, 68, internal.LogLevelInfo, "sync.prepare")
+			syncdLogs, err := a.syncFromMaster(pool, master, req.SlaveServerIDs, remoteTarget, slaveTargetBase, req.SlaveRemotePaths, isFile, baseName)
+			if err != nil {
+				if strings.Contains(strings.ToLower(err.Error()), "permission denied") {
+					fail(65, "sync.failedPermission", err)
+				} else {
+					fail(65, "sync.failed", err)
+				}
+				return
+			}
+			progressSteps := []int{69, 70, 71, 72, 73, 74}
+			lines := make([]string, 0, len(syncdLogs))
+			for i, msg := range syncdLogs {
+				p := 70
+				if i < len(progressSteps) {
+					p = progressSteps[i]
+				}
+				level := internal.LogLevelInfo
+				if msg.Key == "syncd.noTimeout" {
+					level = internal.LogLevelWarn
+				}
+				emitMsg(internal.TaskStatusSyncing, p, internal.LogEntry{Level: level, NodeID: master.ID}, msg)
+				lines = append(lines, a.i18n.Render(msg))
+			}
+			emit(internal.TaskStatusSyncing, 75, internal.LogLevelInfo, "sync.cleaned")
+			emit(internal.TaskStatusSyncing, 77, internal.LogLevelInfo, "sync.done")
+			endStep(internal.RunStepSuccess, strings.Join(lines, "\n"), "")
 		}
 	}
+	checkpoint.SyncTargets = syncTargets
+	saveCheckpoint(internal.RunPhaseSync)
 
-	emit := func(status internal.TaskStatus, progress int, logLine string) {
-		logWithTime := fmt.Sprintf("[%s] %s", time.Now().Format("2006-01-02 15:04:05"), logLine)
-		runtime.EventsEmit(a.ctx, "task:event", internal.TaskEvent{
-			TaskID:   req.TaskID,
-			RunID:    runID,
-			Status:   status,
-			Progress: progress,
-			Log:      logWithTime,
-		})
-		if a.taskService != nil {
-			_ = a.taskService.UpdateTaskState(req.TaskID, status, progress)
-			if runID != "" {
-				_ = a.taskService.AppendRunLog(runID, status, progress, logWithTime)
+	phase = internal.RunPhaseExecute
+	emit(internal.TaskStatusExecuting, 85, internal.LogLevelInfo, "execute.start")
+	if len(req.Commands) > 0 {
+		for _, id := range append([]string{req.MasterServerID}, req.SlaveServerIDs...) {
+			if n, err := a.nodeService.GetNode(id); err == nil && n.Protocol.IsFTP() {
+				emitMsg(internal.TaskStatusExecuting, 85, internal.LogEntry{Level: internal.LogLevelWarn, NodeID: n.ID}, i18n.New("execute.skipFTP", n.Name))
 			}
 		}
 	}
-
-	emit(internal.TaskStatusDownloading, 5, "[信息] 启动自动化分发流水线...")
-
-	resource, err := a.svnService.GetResource(req.SVNResourceID)
-	if err != nil {
-		emit(internal.TaskStatusFailed, 5, "[错误] 未找到 SVN 资源，任务终止。")
-		return
-	}
-
-	cacheDir := filepath.Join(a.dataDir, "svn-cache", req.SVNResourceID)
-	emit(internal.TaskStatusDownloading, 15, "正在建立 SVN 连接，准备拉取最新内容 (HEAD) ...")
-
-	password := ""
-	if a.credStore != nil && resource.Username != "" {
-		if stored, err := a.credStore.GetSVNPassword(req.SVNResourceID, resource.Username); err == nil {
-			password = stored
+	onNode := func(node *internal.Node, started time.Time, output string, err error) {
+		fields := map[string]string{"durationMs": fmt.Sprint(time.Since(started).Milliseconds())}
+		if err != nil {
+			recordStep(node.ID, a.i18n.T("step.execute"), started, internal.RunStepFailed, output, a.i18n.Error(err))
+			emitMsg(internal.TaskStatusExecuting, 85, internal.LogEntry{Level: internal.LogLevelError, NodeID: node.ID, Fields: fields}, i18n.New("execute.nodeFailed", node.Name, err))
+			return
 		}
+		recordStep(node.ID, a.i18n.T("step.execute"), started, internal.RunStepSuccess, output, "")
+		emitMsg(internal.TaskStatusExecuting, 85, internal.LogEntry{Level: internal.LogLevelInfo, NodeID: node.ID, Fields: fields}, i18n.New("execute.nodeDone", node.Name))
 	}
-
-	if err := os.MkdirAll(cacheDir, 0755); err != nil {
-		emit(internal.TaskStatusFailed, 15, fmt.Sprintf("[错误] 创建缓存目录失败：%v", err))
+	if err := a.executeCommandsOnNodes(pool, req.Commands, req.MasterServerID, req.SlaveServerIDs, onNode); err != nil {
+		fail(85, "execute.failed", err)
 		return
 	}
+	saveCheckpoint(internal.RunPhaseExecute)
 
-	exportDest := cacheDir
-	baseName := path.Base(strings.TrimRight(resource.URL, "/"))
-	if resource.Type == internal.SVNResourceFile {
-		if baseName == "" || baseName == "." || baseName == "/" {
-			baseName = "package.bin"
-		}
-		exportDest = filepath.Join(cacheDir, baseName)
-	} else if baseName != "" && baseName != "." && baseName != "/" {
-		exportDest = filepath.Join(cacheDir, baseName)
+	if dials, reuses := pool.Stats(); dials > 0 {
+		emit(internal.TaskStatusExecuting, 99, internal.LogLevelInfo, "pipeline.sshPool", dials, reuses)
 	}
+	emit(internal.TaskStatusSuccess, 100, internal.LogLevelSuccess, "pipeline.success")
+}
 
-	if resource.Type == internal.SVNResourceFile {
-		if err := a.svnClient.CatToFile(a.ctx, resource.URL, resource.Username, password, "", exportDest); err != nil {
-			emit(internal.TaskStatusFailed, 15, fmt.Sprintf("[错误] SVN 检出失败：%v", err))
-			return
-		}
-	} else if err := a.svnClient.Export(a.ctx, resource.URL, resource.Username, password, "", exportDest); err != nil {
-		emit(internal.TaskStatusFailed, 15, fmt.Sprintf("[错误] SVN 检出失败：%v", err))
-		return
+// verifyRemoteChecksum 校验远端资源与本地导出内容是否一致
+func (a *App) verifyRemoteChecksum(pool *ssh.Pool, node *internal.Node, remotePath string, isDir bool, expected string) (bool, error) {
+	client, release, err := a.connectSSH(pool, node)
+	if err != nil {
+		return false, err
 	}
-	emit(internal.TaskStatusDownloading, 30, fmt.Sprintf("SVN 资源检出完成。缓存路径: %s", exportDest))
+	defer release()
 
-	master, err := a.nodeService.GetNode(req.MasterServerID)
+	output, err := client.ExecuteCommand(checksum.RemoteCommand(remotePath, isDir))
 	if err != nil {
-		emit(internal.TaskStatusFailed, 30, "[错误] 未找到主控节点，任务终止。")
-		return
+		return false, err
+	}
+	sum, err := checksum.ParseRemote(output, isDir)
+	if err != nil {
+		return false, err
 	}
+	return sum == expected, nil
+}
 
-	remoteTarget := req.RemotePath
-	if strings.TrimSpace(remoteTarget) == "" {
-		remoteTarget = "/tmp/deploymaster"
+// uploadToNode 按节点协议上传本地路径；progress 汇报累计字节数，可为 nil
+func (a *App) uploadToNode(pool *ssh.Pool, node *internal.Node, localPath, remotePath string, progress transfer.ProgressFunc) error {
+	remote := remotePath
+	if strings.TrimSpace(remote) == "" {
+		remote = "/tmp/deploymaster"
 	}
-	if resource.Type == internal.SVNResourceFile {
-		baseName = path.Base(strings.TrimRight(resource.URL, "/"))
-		if baseName == "" || baseName == "." || baseName == "/" {
-			baseName = "package.bin"
+
+	if node.Protocol.IsFTP() {
+		// FTP 数据连接使用动态端口，无法经 SSH 跳板转发
+		if node.JumpHostID != "" {
+			return i18n.New("sync.jumpUnsupported", node.Name)
 		}
-		remoteTarget = filepath.ToSlash(filepath.Join(remoteTarget, baseName))
+		ft := transfer.NewFTP(a.ftpConfig(node))
+		if err := ft.Connect(); err != nil {
+			return err
+		}
+		defer ft.Close()
+		return transfer.UploadPath(ft, localPath, remote, progress)
 	}
 
-	emit(internal.TaskStatusUploading, 45, fmt.Sprintf("正在通过 %s 上传资源至主控机: %s", master.Protocol, remoteTarget))
-	if err := a.uploadToNode(master, exportDest, remoteTarget); err != nil {
-		emit(internal.TaskStatusFailed, 45, fmt.Sprintf("[错误] 上传至主控机失败：%v", err))
-		return
+	client, release, err := a.connectSSH(pool, node)
+	if err != nil {
+		return err
 	}
-	emit(internal.TaskStatusUploading, 55, fmt.Sprintf("主控机资源上传完成：%s", remoteTarget))
+	defer release()
 
-	slaveTargetBase := req.SlaveRemotePath
-	if strings.TrimSpace(slaveTargetBase) == "" {
-		slaveTargetBase = req.RemotePath
-	}
-	if strings.TrimSpace(slaveTargetBase) == "" {
-		slaveTargetBase = "/tmp/deploymaster"
+	// SCP 以单个会话传输整个目录，不经过 Transport
+	if node.Protocol == internal.ProtocolSCP {
+		return client.UploadPathSCP(localPath, remote, progress)
 	}
 
-	emit(internal.TaskStatusSyncing, 65, fmt.Sprintf("主控机开始同步 %d 台从机...", len(req.SlaveServerIDs)))
-	emit(internal.TaskStatusSyncing, 68, "准备主控机临时同步服务 /tmp/deploymaster-syncd（自动校验版本，必要时覆盖上传）")
-	syncdLogs, err := a.syncFromMaster(master, req.SlaveServerIDs, remoteTarget, slaveTargetBase, req.SlaveRemotePaths, resource.Type == internal.SVNResourceFile, baseName)
+	sftpClient, err := client.NewSFTPClient()
 	if err != nil {
-		msg := err.Error()
-		if strings.Contains(strings.ToLower(msg), "permission denied") {
-			msg = msg + "（请检查从机目标目录权限，或改用可写目录如 /tmp）"
-		}
-		if strings.HasPrefix(msg, "从机同步失败：") {
-			emit(internal.TaskStatusFailed, 65, fmt.Sprintf("[错误] %s", msg))
-		} else {
-			emit(internal.TaskStatusFailed, 65, fmt.Sprintf("[错误] 从机同步失败：%s", msg))
-		}
-		return
-	}
-	if len(syncdLogs) > 0 {
-		progressSteps := []int{69, 70, 71, 72, 73, 74}
-		for i, line := range syncdLogs {
-			p := 70
-			if i < len(progressSteps) {
-				p = progressSteps[i]
-			}
-			emit(internal.TaskStatusSyncing, p, line)
-		}
+		return err
 	}
-	emit(internal.TaskStatusSyncing, 75, "临时同步服务执行完成，已清理 /tmp/deploymaster-syncd")
-	emit(internal.TaskStatusSyncing, 77, "主控机同步从机完成。")
+	t := transfer.WrapSFTP(sftpClient)
+	defer t.Close()
+	// 保活判定连接失效时返回明确的断开原因，而非 EOF 等底层错误
+	return client.LostError(transfer.UploadPath(t, localPath, remote, progress))
+}
 
-	emit(internal.TaskStatusExecuting, 85, "正在启动远程自定义脚本执行序列...")
-	if err := a.executeCommandsOnNodes(req.Commands, req.MasterServerID, req.SlaveServerIDs); err != nil {
-		emit(internal.TaskStatusFailed, 85, fmt.Sprintf("[错误] 远程脚本执行失败：%v", err))
-		return
+// directUploadConcurrency 客户端直传模式下同时上传的从机数
+const directUploadConcurrency = 4
+
+// uploadToSlaves 客户端直传：由本机并行上传到各从机，不经主控机中转，
+// 适用于小规模节点或主控机无法访问的从机。onNode 串行接收每个节点的结果，全部结束后返回首个错误
+func (a *App) uploadToSlaves(pool *ssh.Pool, req internal.TaskRunRequest, localPath string, isFile bool, baseName string, onNode func(node *internal.Node, started time.Time, dest string, err error)) error {
+	nodes := make([]*internal.Node, 0, len(req.SlaveServerIDs))
+	for _, id := range req.SlaveServerIDs {
+		node, err := a.nodeService.GetNode(id)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
 	}
 
-	emit(internal.TaskStatusSuccess, 100, "✓ 任务执行成功。所有节点已同步至最新状态。")
+	return uploadConcurrently(nodes, directUploadConcurrency,
+		func(node *internal.Node) string { return slaveDestination(req, node.ID, isFile, baseName) },
+		func(node *internal.Node, dest string) error { return a.uploadToNode(pool, node, localPath, dest, nil) },
+		onNode)
 }
 
-func (a *App) uploadToNode(node *internal.Node, localPath, remotePath string) error {
-	client, err := a.createSSHClient(node)
-	if err != nil {
-		return err
+// uploadConcurrently 最多 limit 个节点同时执行 upload；onNode 串行接收每个节点的结果，全部结束后返回首个错误
+func uploadConcurrently(nodes []*internal.Node, limit int, destination func(node *internal.Node) string, upload func(node *internal.Node, dest string) error, onNode func(node *internal.Node, started time.Time, dest string, err error)) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, limit)
+	for _, node := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(node *internal.Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			dest := destination(node)
+			started := time.Now()
+			err := upload(node, dest)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", node.Name, err)
+			}
+			if onNode != nil {
+				onNode(node, started, dest, err)
+			}
+		}(node)
 	}
-	defer client.Close()
+	wg.Wait()
+	return firstErr
+}
 
-	if err := client.Connect(node.IP, node.Port); err != nil {
-		return err
+// canReuseExport 重试时判断能否复用导出缓存：上次已导出到同一路径，修订号未变，且本地内容校验和一致
+// changed 表示满足前置条件但缓存内容已变化，需要重新导出
+func canReuseExport(prev *internal.RunCheckpoint, exportDest, revision string, localSum func() (string, error)) (reuse, changed bool) {
+	if !prev.HasCompleted(internal.RunPhaseExport) || prev.ExportPath != exportDest || prev.Checksum == "" ||
+		(revision != "" && revision != prev.Revision) {
+		return false, false
+	}
+	if sum, err := localSum(); err != nil || sum != prev.Checksum {
+		return false, true
 	}
+	return true, false
+}
 
-	sftpClient, err := client.NewSFTPClient()
-	if err != nil {
-		return err
+// canReuseUpload 重试时判断能否跳过主控机上传：导出已复用，上次已上传到同一主控的同一路径，且远端内容校验通过
+// recheck 表示满足前置条件但远端校验未通过，需要重新上传
+func canReuseUpload(prev *internal.RunCheckpoint, reuseExport bool, masterID, remoteTarget string, verify func() (bool, error)) (reuse, recheck bool) {
+	if !reuseExport || !prev.HasCompleted(internal.RunPhaseUpload) || prev.MasterID != masterID || prev.MasterPath != remoteTarget {
+		return false, false
 	}
-	defer sftpClient.Close()
+	if ok, err := verify(); err != nil || !ok {
+		return false, true
+	}
+	return true, false
+}
 
-	remote := remotePath
-	if strings.TrimSpace(remote) == "" {
-		remote = "/tmp/deploymaster"
+// canSkipSync 重试时判断能否跳过同步：同步源内容已复用，且上次已同步到相同的从机与目标路径
+func canSkipSync(prev *internal.RunCheckpoint, reused bool, targets map[string]string) bool {
+	return reused && prev.SyncedTo(targets)
+}
+
+// slaveDestination 计算从机目标路径：节点独立路径优先，其次从机统一路径、主控路径
+func slaveDestination(req internal.TaskRunRequest, slaveID string, isFile bool, baseName string) string {
+	dest := req.SlaveRemotePath
+	if custom, ok := req.SlaveRemotePaths[slaveID]; ok && strings.TrimSpace(custom) != "" {
+		dest = custom
+	}
+	if strings.TrimSpace(dest) == "" {
+		dest = req.RemotePath
+	}
+	if strings.TrimSpace(dest) == "" {
+		dest = "/tmp/deploymaster"
+	}
+	if isFile && baseName != "" {
+		return filepath.ToSlash(filepath.Join(dest, baseName))
 	}
-	return ssh.UploadPath(sftpClient, localPath, remote)
+	return filepath.ToSlash(dest)
 }
 
-func (a *App) executeCommandsOnNodes(commands []string, masterID string, slaveIDs []string) error {
+// executeCommandsOnNodes 依次在主控机与从机执行命令，onNode 接收每个节点的执行结果
+func (a *App) executeCommandsOnNodes(pool *ssh.Pool, commands []string, masterID string, slaveIDs []string, onNode func(node *internal.Node, started time.Time, output string, err error)) error {
 	if len(commands) == 0 {
 		return nil
 	}
 
-	ids := append([]string{masterID}, slaveIDs...)
+	// 客户端直传可不指定主控机，此时只在从机执行
+	ids := slaveIDs
+	if masterID != "" {
+		ids = append([]string{masterID}, slaveIDs...)
+	}
 	for _, id := range ids {
 		node, err := a.nodeService.GetNode(id)
 		if err != nil {
 			return err
 		}
-		if err := a.executeCommandsOnNode(node, commands); err != nil {
+		// FTP 节点只接收文件，无法执行命令
+		if node.Protocol.IsFTP() {
+			continue
+		}
+		started := time.Now()
+		output, err := a.executeCommandsOnNode(pool, node, commands)
+		if onNode != nil {
+			onNode(node, started, output, err)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -784,13 +2288,26 @@ func (a *App) executeCommandsOnNodes(commands []string, masterID string, slaveID
 }
 
 type syncdSlave struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Host       string `json:"host"`
-	Port       int    `json:"port"`
-	User       string `json:"user"`
-	Password   string `json:"password"`
-	RemotePath string `json:"remotePath"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Host       string     `json:"host"`
+	Port       int        `json:"port"`
+	User       string     `json:"user"`
+	Password   string     `json:"password"`
+	RemotePath string     `json:"remotePath"`
+	Protocol   string     `json:"protocol,omitempty"` // SFTP（默认）、SCP 或 FTP/FTPS
+	Jumps      []syncdHop `json:"jumps,omitempty"`    // 跳板链，按连接顺序排列
+
+	Relay    bool         `json:"relay,omitempty"`    // 仅作中转，分发后清理暂存文件
+	Children []syncdSlave `json:"children,omitempty"` // 经由本节点分发的下级节点
+}
+
+// syncdHop 主控机同步服务经由的跳板机，与从机一样仅支持密码认证
+type syncdHop struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
 }
 
 type syncdPayload struct {
@@ -802,26 +2319,31 @@ type syncdPayload struct {
 	Slaves     []syncdSlave `json:"slaves"`
 }
 
-func (a *App) ensureSyncdOnMaster(client *ssh.Client, remotePath string) (string, string, bool, int, string, error) {
+func (a *App) ensureSyncdOnMaster(client *ssh.Client, protocol internal.Protocol, remotePath string) (string, string, bool, int, string, error) {
 	arch := "amd64"
 	osName := "unknown"
-	if output, err := client.ExecuteCommand("uname -s"); err == nil {
-		osName = strings.TrimSpace(strings.ToLower(output))
+	// 一次会话同时取得系统与架构
+	if output, err := client.ExecuteCommand("uname -sm"); err == nil {
+		fields := strings.Fields(strings.ToLower(output))
+		if len(fields) > 0 {
+			osName = fields[0]
+		}
 		switch osName {
 		case "linux", "darwin":
 		default:
-			return "", osName, false, 0, "", fmt.Errorf("主控机系统暂不支持同步服务：仅支持 Linux/macOS")
+			return "", osName, false, 0, "", i18n.New("syncd.unsupportedOS")
+		}
+		rawArch := ""
+		if len(fields) > 1 {
+			rawArch = fields[1]
 		}
-	}
-	if output, err := client.ExecuteCommand("uname -m"); err == nil {
-		rawArch := strings.TrimSpace(strings.ToLower(output))
 		switch rawArch {
 		case "x86_64", "amd64":
 			arch = "amd64"
 		case "aarch64", "arm64":
 			arch = "arm64"
 		default:
-			return "", osName, false, 0, "", fmt.Errorf("主控机架构暂不支持同步服务：仅支持 amd64/arm64")
+			return "", osName, false, 0, "", i18n.New("syncd.unsupportedArch")
 		}
 	}
 
@@ -848,21 +2370,7 @@ func (a *App) ensureSyncdOnMaster(client *ssh.Client, remotePath string) (string
 		return "", osName, false, 0, "", fmt.Errorf("syncd binary not embedded")
 	}
 
-	sftpClient, err := client.NewSFTPClient()
-	if err != nil {
-		return "", osName, false, 0, "", err
-	}
-	defer sftpClient.Close()
-
-	dst, err := sftpClient.Create(remotePath)
-	if err != nil {
-		return "", osName, false, 0, "", err
-	}
-	if _, err := io.Copy(dst, bytes.NewReader(bin)); err != nil {
-		_ = dst.Close()
-		return "", osName, false, 0, "", err
-	}
-	if err := dst.Close(); err != nil {
+	if err := writeRemoteFile(client, protocol, remotePath, bin); err != nil {
 		return "", osName, false, 0, "", err
 	}
 
@@ -874,48 +2382,65 @@ func (a *App) ensureSyncdOnMaster(client *ssh.Client, remotePath string) (string
 	return arch, osName, true, len(bin), checksum, nil
 }
 
-func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePath string, slaveRemotePath string, slaveRemotePaths map[string]string, isFile bool, baseName string) ([]string, error) {
-	if len(slaveIDs) == 0 {
-		return []string{}, nil
+// writeRemoteFile 按节点传输协议写入远程文件
+func writeRemoteFile(client *ssh.Client, protocol internal.Protocol, remotePath string, data []byte) error {
+	if protocol == internal.ProtocolSCP {
+		return client.WriteFileSCP(remotePath, data, 0755)
 	}
 
-	client, err := a.createSSHClient(master)
+	sftpClient, err := client.NewSFTPClient()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer sftpClient.Close()
+
+	dst, err := sftpClient.Create(remotePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, bytes.NewReader(data)); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// syncFromMaster 通过主控机同步服务分发到从机，返回过程日志消息
+func (a *App) syncFromMaster(pool *ssh.Pool, master *internal.Node, slaveIDs []string, remotePath string, slaveRemotePath string, slaveRemotePaths map[string]string, isFile bool, baseName string) ([]*i18n.Message, error) {
+	if len(slaveIDs) == 0 {
+		return nil, nil
 	}
-	defer client.Close()
 
-	if err := client.Connect(master.IP, master.Port); err != nil {
+	client, release, err := a.connectSSH(pool, master)
+	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	syncdPath := "/tmp/deploymaster-syncd"
-	arch, osName, updated, binSize, checksum, err := a.ensureSyncdOnMaster(client, syncdPath)
+	arch, osName, updated, binSize, checksum, err := a.ensureSyncdOnMaster(client, master.Protocol, syncdPath)
 	if err != nil {
-		return nil, fmt.Errorf("部署同步服务失败：%v", err)
+		return nil, i18n.New("syncd.deployFailed", err)
 	}
 	defer func() {
 		_, _ = client.ExecuteCommand("rm -f " + shellescape.Quote(syncdPath))
 	}()
-	logs := make([]string, 0, 16)
-	logs = append(logs, fmt.Sprintf("同步服务路径：%s", syncdPath))
-	osArchNote := fmt.Sprintf("主控机系统检测：%s/%s", osName, arch)
-	var syncdNote string
+	logs := make([]*i18n.Message, 0, 16)
+	logs = append(logs, i18n.New("syncd.path", syncdPath))
+	logs = append(logs, i18n.New("syncd.osArch", osName, arch))
 	if updated {
-		syncdNote = fmt.Sprintf("同步服务已更新：%s (version=%s, arch=%s)", syncdPath, syncd.Version, arch)
+		logs = append(logs, i18n.New("syncd.updated", syncdPath, syncd.Version, arch))
 	} else {
-		syncdNote = fmt.Sprintf("同步服务已就绪：%s (version=%s, arch=%s)", syncdPath, syncd.Version, arch)
+		logs = append(logs, i18n.New("syncd.ready", syncdPath, syncd.Version, arch))
 	}
-	logs = append(logs, osArchNote)
-	logs = append(logs, syncdNote)
 	if binSize > 0 && checksum != "" {
-		logs = append(logs, fmt.Sprintf("同步服务校验：size=%dB crc32=%s", binSize, checksum))
+		logs = append(logs, i18n.New("syncd.checksum", binSize, checksum))
 	}
 
-	if output, err := client.ExecuteCommand("df -k /tmp | tail -n +2 | awk '{print $4\"K\"\"/\"$2\"K\"\"(\"$5\" used)\"}'"); err == nil {
-		info := strings.TrimSpace(output)
-		if info != "" {
-			logs = append(logs, fmt.Sprintf("/tmp 磁盘占用：%s", info))
+	if output, err := client.ExecuteCommand(telemetry.Command([]string{"/tmp"})); err == nil {
+		if metrics, err := telemetry.Parse(output); err == nil && len(metrics.Disks) > 0 {
+			disk := metrics.Disks[0]
+			logs = append(logs, i18n.New("syncd.diskUsage", formatBytes(disk.Free), formatBytes(disk.Total), fmt.Sprintf("%.0f%%", disk.UsedPercent)))
 		}
 	}
 
@@ -925,8 +2450,6 @@ func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePat
 	}
 	src = filepath.ToSlash(src)
 
-	slaves := make([]syncdSlave, 0, len(slaveIDs))
-	slaveNames := make([]string, 0, len(slaveIDs))
 	dest := slaveRemotePath
 	if strings.TrimSpace(dest) == "" {
 		dest = remotePath
@@ -934,24 +2457,41 @@ func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePat
 	if strings.TrimSpace(dest) == "" {
 		dest = "/tmp/deploymaster"
 	}
-	if isFile && baseName != "" {
-		dest = filepath.ToSlash(filepath.Join(dest, baseName))
-	} else {
-		dest = filepath.ToSlash(dest)
+	withBase := func(dir string) string {
+		if isFile && baseName != "" {
+			return filepath.ToSlash(filepath.Join(dir, baseName))
+		}
+		return filepath.ToSlash(dir)
 	}
+	dest = withBase(dest)
+	// 仅作中转的节点把文件暂存在独立目录，分发完成后由 syncd 清理
+	relayDest := withBase(fmt.Sprintf("/tmp/deploymaster-relay-%d", time.Now().UnixNano()))
 
-	for _, slaveID := range slaveIDs {
-		slave, err := a.nodeService.GetNode(slaveID)
-		if err != nil {
-			return nil, err
-		}
+	tree, err := a.nodeService.RelayTree(master.ID, slaveIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	slaveNames := make([]string, 0, len(slaveIDs))
+	var buildSlave func(r *node.RelayNode) (syncdSlave, error)
+	buildSlave = func(r *node.RelayNode) (syncdSlave, error) {
+		slave := r.Node
 		user := slave.Username
 		if strings.TrimSpace(user) == "" {
 			user = "root"
 		}
 
-		if slave.AuthMethod != internal.AuthMethodPassword {
-			return nil, fmt.Errorf("从机同步失败：主控机同步服务仅支持密码认证，从机 %s 请改为密码认证或改用客户端直传模式", slave.Name)
+		if slave.AuthMethod != internal.AuthMethodPassword && !slave.Protocol.IsFTP() {
+			return syncdSlave{}, i18n.New("sync.passwordAuthOnly", slave.Name)
+		}
+		if slave.Protocol.IsFTP() && len(r.Children) > 0 {
+			return syncdSlave{}, i18n.New("sync.ftpRelay", slave.Name)
+		}
+		if slave.Protocol.IsFTP() && slave.JumpHostID != "" {
+			return syncdSlave{}, i18n.New("sync.jumpUnsupported", slave.Name)
+		}
+		if slave.Protocol.IsFTP() && strings.TrimSpace(slave.Username) == "" {
+			user = "anonymous"
 		}
 
 		password := ""
@@ -960,22 +2500,18 @@ func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePat
 				password = stored
 			}
 		}
-		if strings.TrimSpace(password) == "" {
-			return nil, fmt.Errorf("从机同步失败：未找到从机 %s 的密码，请先保存密码", slave.Name)
+		if strings.TrimSpace(password) == "" && !(slave.Protocol.IsFTP() && user == "anonymous") {
+			return syncdSlave{}, i18n.New("sync.passwordMissing", slave.Name)
 		}
 
 		slaveDest := dest
-		if len(slaveRemotePaths) > 0 {
-			if custom, ok := slaveRemotePaths[slaveID]; ok && strings.TrimSpace(custom) != "" {
-				if isFile && baseName != "" {
-					slaveDest = filepath.ToSlash(filepath.Join(custom, baseName))
-				} else {
-					slaveDest = filepath.ToSlash(custom)
-				}
-			}
+		if !r.Target {
+			slaveDest = relayDest
+		} else if custom, ok := slaveRemotePaths[slave.ID]; ok && strings.TrimSpace(custom) != "" {
+			slaveDest = withBase(custom)
 		}
 
-		slaves = append(slaves, syncdSlave{
+		result := syncdSlave{
 			ID:         slave.ID,
 			Name:       slave.Name,
 			Host:       slave.IP,
@@ -983,16 +2519,45 @@ func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePat
 			User:       user,
 			Password:   password,
 			RemotePath: slaveDest,
-		})
-		name := slave.Name
-		if strings.TrimSpace(name) == "" {
-			name = slave.IP
+			Protocol:   string(slave.Protocol),
+			Relay:      !r.Target,
+		}
+		jumps, err := a.syncdHops(slave)
+		if err != nil {
+			return syncdSlave{}, err
+		}
+		result.Jumps = jumps
+		for _, child := range r.Children {
+			c, err := buildSlave(child)
+			if err != nil {
+				return syncdSlave{}, err
+			}
+			result.Children = append(result.Children, c)
+		}
+		if r.Target {
+			slaveNames = append(slaveNames, nodeDisplayName(slave))
+		}
+		return result, nil
+	}
+
+	slaves := make([]syncdSlave, 0, len(tree))
+	total := 0
+	relayed := false
+	for _, r := range tree {
+		slave, err := buildSlave(r)
+		if err != nil {
+			return nil, err
 		}
-		slaveNames = append(slaveNames, name)
+		slaves = append(slaves, slave)
+		total += r.Count()
+		relayed = relayed || len(r.Children) > 0
 	}
 
 	sort.Strings(slaveNames)
-	logs = append(logs, fmt.Sprintf("同步目标从机：%s", strings.Join(slaveNames, ", ")))
+	logs = append(logs, i18n.New("syncd.targets", strings.Join(slaveNames, ", ")))
+	if relayed {
+		logs = append(logs, i18n.New("syncd.relayTree", formatRelayTree(tree)))
+	}
 
 	payload := syncdPayload{
 		Version:    syncd.Version,
@@ -1008,50 +2573,205 @@ func (a *App) syncFromMaster(master *internal.Node, slaveIDs []string, remotePat
 		return nil, err
 	}
 	payloadB64 := base64.StdEncoding.EncodeToString(raw)
-	timeoutSeconds := int((time.Duration(len(slaves)) * 120 * time.Second).Seconds())
+	timeoutSeconds := int((time.Duration(total) * 120 * time.Second).Seconds())
 	cmd := fmt.Sprintf("%s --payload %s", shellescape.Quote(syncdPath), shellescape.Quote(payloadB64))
 	if _, err := client.ExecuteCommand("command -v timeout"); err == nil {
 		cmd = fmt.Sprintf("timeout %ds %s --payload %s", timeoutSeconds, shellescape.Quote(syncdPath), shellescape.Quote(payloadB64))
 	} else {
-		logs = append(logs, "注意：主控机未安装 timeout，无法设置同步超时保护")
+		logs = append(logs, i18n.New("syncd.noTimeout"))
 	}
-	logs = append(logs, fmt.Sprintf("同步执行开始：%s", time.Now().Format("2006-01-02 15:04:05")))
-	if output, err := client.ExecuteCommand(cmd); err != nil {
+	logs = append(logs, i18n.New("syncd.begin", time.Now().Format("2006-01-02 15:04:05")))
+	// syncd 完成前不输出内容，不适用空闲超时，运行时长由 timeout 限制
+	if output, err := client.ExecuteLongCommand(cmd); err != nil {
 		msg := strings.TrimSpace(output)
 		if msg == "" {
 			msg = err.Error()
 		}
-		return nil, fmt.Errorf("从机同步失败：%s", msg)
+		return nil, errors.New(msg)
 	}
 
-	logs = append(logs, fmt.Sprintf("同步执行结束：%s", time.Now().Format("2006-01-02 15:04:05")))
-	logs = append(logs, fmt.Sprintf("同步耗时预估：%ds（按 %d 台从机计算）", timeoutSeconds, len(slaves)))
+	logs = append(logs, i18n.New("syncd.end", time.Now().Format("2006-01-02 15:04:05")))
+	logs = append(logs, i18n.New("syncd.estimate", timeoutSeconds, total))
 	return logs, nil
 }
 
-func (a *App) executeCommandsOnNode(node *internal.Node, commands []string) error {
-	client, err := a.createSSHClient(node)
-	if err != nil {
-		return err
+// formatRelayTree 将分发树格式化为 relay → {a, b}, c 的形式
+func formatRelayTree(tree []*node.RelayNode) string {
+	parts := make([]string, 0, len(tree))
+	for _, r := range tree {
+		part := nodeDisplayName(r.Node)
+		if len(r.Children) > 0 {
+			part += " → {" + formatRelayTree(r.Children) + "}"
+		}
+		parts = append(parts, part)
 	}
-	defer client.Close()
+	return strings.Join(parts, ", ")
+}
 
-	if err := client.Connect(node.IP, node.Port); err != nil {
-		return err
+func nodeDisplayName(n *internal.Node) string {
+	if strings.TrimSpace(n.Name) == "" {
+		return n.IP
+	}
+	return n.Name
+}
+
+// formatBytes 将字节数格式化为 B/KB/MB/GB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit && exp < 2; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMG"[exp])
+}
+
+// executeCommandsOnNode 在节点上依次执行命令，返回合并的命令输出
+func (a *App) executeCommandsOnNode(pool *ssh.Pool, node *internal.Node, commands []string) (string, error) {
+	client, release, err := a.connectSSH(pool, node)
+	if err != nil {
+		return "", err
 	}
+	defer release()
 
+	var output strings.Builder
 	for _, cmd := range commands {
 		if strings.TrimSpace(cmd) == "" {
 			continue
 		}
-		if _, err := client.ExecuteCommand(cmd); err != nil {
-			return err
+		out, err := client.ExecuteCommand(cmd)
+		output.WriteString("$ " + cmd + "\n" + out)
+		if out != "" && !strings.HasSuffix(out, "\n") {
+			output.WriteString("\n")
+		}
+		if err != nil {
+			return output.String(), err
 		}
 	}
-	return nil
+	return output.String(), nil
+}
+
+// ftpConfig 使用节点保存的密码生成 FTP/FTPS 连接参数
+func (a *App) ftpConfig(node *internal.Node) transfer.FTPConfig {
+	username := node.Username
+	if strings.TrimSpace(username) == "" {
+		username = "anonymous"
+	}
+	password := ""
+	if a.credStore != nil {
+		if stored, err := a.credStore.GetPassword(node.ID, username); err == nil {
+			password = stored
+		}
+	}
+	return transfer.FTPConfig{
+		Host:     node.IP,
+		Port:     node.Port,
+		User:     username,
+		Password: password,
+		TLS:      node.Protocol == internal.ProtocolFTPS,
+	}
 }
 
+// connectSSH 返回已连接的节点客户端；pool 非 nil 时复用池中连接，release 为空操作，
+// 否则新建连接，release 关闭连接
+func (a *App) connectSSH(pool *ssh.Pool, node *internal.Node) (*ssh.Client, func(), error) {
+	dial := func() (*ssh.Client, error) {
+		client, err := a.createSSHClient(node)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.Connect(node.IP, node.Port); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		return client, nil
+	}
+	if pool == nil {
+		client, err := dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() { _ = client.Close() }, nil
+	}
+	client, err := pool.Get(node.ID, dial)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, func() {}, nil
+}
+
+// createSSHClient 创建节点的 SSH 客户端并设置跳板链，调用方负责 Connect
 func (a *App) createSSHClient(node *internal.Node) (*ssh.Client, error) {
+	client, err := a.newSSHClient(node)
+	if err != nil {
+		return nil, err
+	}
+	hops, err := a.jumpHosts(node)
+	if err != nil {
+		return nil, err
+	}
+	client.SetJumpHosts(hops...)
+	client.SetKeepAlive(a.sshKeepAlive())
+	return client, nil
+}
+
+// syncdHops 解析从机的跳板链供主控机同步服务使用，跳板机需为密码认证且已保存密码
+func (a *App) syncdHops(slave *internal.Node) ([]syncdHop, error) {
+	if slave.JumpHostID == "" {
+		return nil, nil
+	}
+	chain, err := a.nodeService.JumpChain(slave.ID)
+	if err != nil {
+		return nil, err
+	}
+	hops := make([]syncdHop, 0, len(chain))
+	for _, jump := range chain {
+		if jump.AuthMethod != internal.AuthMethodPassword {
+			return nil, i18n.New("sync.passwordAuthOnly", jump.Name)
+		}
+		user := jump.Username
+		if strings.TrimSpace(user) == "" {
+			user = "root"
+		}
+		password := ""
+		if a.credStore != nil {
+			if stored, err := a.credStore.GetPassword(jump.ID, user); err == nil {
+				password = stored
+			}
+		}
+		if strings.TrimSpace(password) == "" {
+			return nil, i18n.New("sync.passwordMissing", jump.Name)
+		}
+		hops = append(hops, syncdHop{Host: jump.IP, Port: jump.Port, User: user, Password: password})
+	}
+	return hops, nil
+}
+
+// jumpHosts 解析节点的跳板链，为每一跳创建使用其自身凭据的客户端
+func (a *App) jumpHosts(node *internal.Node) ([]ssh.JumpHost, error) {
+	if node.JumpHostID == "" || a.nodeService == nil {
+		return nil, nil
+	}
+	chain, err := a.nodeService.JumpChain(node.ID)
+	if err != nil {
+		return nil, err
+	}
+	hops := make([]ssh.JumpHost, 0, len(chain))
+	for _, hop := range chain {
+		client, err := a.newSSHClient(hop)
+		if err != nil {
+			return nil, fmt.Errorf("jump host %s: %w", hop.Name, err)
+		}
+		hops = append(hops, ssh.JumpHost{Client: client, Host: hop.IP, Port: hop.Port})
+	}
+	return hops, nil
+}
+
+// newSSHClient 按节点认证方式与保存的凭据创建 SSH 客户端
+func (a *App) newSSHClient(node *internal.Node) (*ssh.Client, error) {
 	username := node.Username
 	if strings.TrimSpace(username) == "" {
 		username = "root"
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/transfer"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSlaveDestination(t *testing.T) {
+	req := internal.TaskRunRequest{
+		RemotePath:       "/srv/master",
+		SlaveRemotePath:  "/srv/slave",
+		SlaveRemotePaths: map[string]string{"s1": "/srv/custom", "s2": "  "},
+	}
+	cases := []struct {
+		name     string
+		req      internal.TaskRunRequest
+		slaveID  string
+		isFile   bool
+		baseName string
+		want     string
+	}{
+		{"PerNodePath", req, "s1", false, "", "/srv/custom"},
+		{"BlankPerNodeFallsBack", req, "s2", false, "", "/srv/slave"},
+		{"SlavePath", req, "s3", false, "", "/srv/slave"},
+		{"MasterPath", internal.TaskRunRequest{RemotePath: "/srv/master"}, "s3", false, "", "/srv/master"},
+		{"Default", internal.TaskRunRequest{}, "s3", false, "", "/tmp/deploymaster"},
+		{"FileAppendsBaseName", req, "s1", true, "app.tar.gz", "/srv/custom/app.tar.gz"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slaveDestination(tc.req, tc.slaveID, tc.isFile, tc.baseName); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryReuse(t *testing.T) {
+	prev := &internal.RunCheckpoint{
+		ExportPath:      "/data/svn-cache/r1/app",
+		Revision:        "42",
+		Checksum:        "sum-a",
+		MasterID:        "m1",
+		MasterPath:      "/srv/app",
+		SyncTargets:     map[string]string{"s1": "/srv/app", "s2": "/srv/app"},
+		CompletedPhases: []internal.RunPhase{internal.RunPhaseExport, internal.RunPhaseUpload, internal.RunPhaseSync},
+	}
+	exportOnly := &internal.RunCheckpoint{
+		ExportPath:      prev.ExportPath,
+		Revision:        prev.Revision,
+		Checksum:        prev.Checksum,
+		CompletedPhases: []internal.RunPhase{internal.RunPhaseExport},
+	}
+	sum := func(value string, err error) func() (string, error) {
+		return func() (string, error) { return value, err }
+	}
+	verified := func(ok bool, err error) func() (bool, error) {
+		return func() (bool, error) { return ok, err }
+	}
+	unreachable := errors.New("connection refused")
+
+	t.Run("Export", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			prev        *internal.RunCheckpoint
+			dest        string
+			revision    string
+			localSum    func() (string, error)
+			wantReuse   bool
+			wantChanged bool
+		}{
+			{"Unchanged", prev, prev.ExportPath, "", sum("sum-a", nil), true, false},
+			{"SameRevision", prev, prev.ExportPath, "42", sum("sum-a", nil), true, false},
+			{"NoCheckpoint", nil, prev.ExportPath, "", sum("sum-a", nil), false, false},
+			{"OtherRevision", prev, prev.ExportPath, "43", sum("sum-a", nil), false, false},
+			{"OtherPath", prev, "/data/svn-cache/r2/app", "", sum("sum-a", nil), false, false},
+			{"ChecksumMismatch", prev, prev.ExportPath, "", sum("sum-b", nil), false, true},
+			{"CacheMissing", prev, prev.ExportPath, "", sum("", os.ErrNotExist), false, true},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reuse, changed := canReuseExport(tc.prev, tc.dest, tc.revision, tc.localSum)
+				if reuse != tc.wantReuse || changed != tc.wantChanged {
+					t.Errorf("Expected reuse=%v changed=%v, got reuse=%v changed=%v", tc.wantReuse, tc.wantChanged, reuse, changed)
+				}
+			})
+		}
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			prev        *internal.RunCheckpoint
+			reuseExport bool
+			masterID    string
+			target      string
+			verify      func() (bool, error)
+			wantReuse   bool
+			wantRecheck bool
+		}{
+			{"Unchanged", prev, true, "m1", "/srv/app", verified(true, nil), true, false},
+			{"ExportRedone", prev, false, "m1", "/srv/app", verified(true, nil), false, false},
+			{"NotUploaded", exportOnly, true, "m1", "/srv/app", verified(true, nil), false, false},
+			{"MasterChanged", prev, true, "m2", "/srv/app", verified(true, nil), false, false},
+			{"MasterPathChanged", prev, true, "m1", "/srv/web", verified(true, nil), false, false},
+			{"RemoteChecksumMismatch", prev, true, "m1", "/srv/app", verified(false, nil), false, true},
+			{"RemoteUnreachable", prev, true, "m1", "/srv/app", verified(false, unreachable), false, true},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reuse, recheck := canReuseUpload(tc.prev, tc.reuseExport, tc.masterID, tc.target, tc.verify)
+				if reuse != tc.wantReuse || recheck != tc.wantRecheck {
+					t.Errorf("Expected reuse=%v recheck=%v, got reuse=%v recheck=%v", tc.wantReuse, tc.wantRecheck, reuse, recheck)
+				}
+			})
+		}
+	})
+
+	t.Run("Sync", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			prev    *internal.RunCheckpoint
+			reused  bool
+			targets map[string]string
+			want    bool
+		}{
+			{"Unchanged", prev, true, map[string]string{"s1": "/srv/app", "s2": "/srv/app"}, true},
+			{"SourceRedone", prev, false, map[string]string{"s1": "/srv/app", "s2": "/srv/app"}, false},
+			{"NotSynced", exportOnly, true, map[string]string{"s1": "/srv/app", "s2": "/srv/app"}, false},
+			{"SlaveAdded", prev, true, map[string]string{"s1": "/srv/app", "s2": "/srv/app", "s3": "/srv/app"}, false},
+			{"SlaveRemoved", prev, true, map[string]string{"s1": "/srv/app"}, false},
+			{"SlaveReplaced", prev, true, map[string]string{"s1": "/srv/app", "s3": "/srv/app"}, false},
+			{"TargetPathChanged", prev, true, map[string]string{"s1": "/srv/app", "s2": "/srv/custom"}, false},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				if got := canSkipSync(tc.prev, tc.reused, tc.targets); got != tc.want {
+					t.Errorf("Expected skip=%v, got %v", tc.want, got)
+				}
+			})
+		}
+	})
+}
+
+func TestUploadConcurrently(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "app.bin")
+	if err := os.WriteFile(local, []byte("binary"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	nodes := make([]*internal.Node, 6)
+	mocks := make(map[string]*transfer.Mock)
+	for i := range nodes {
+		nodes[i] = &internal.Node{ID: string(rune('a' + i)), Name: "web-" + string(rune('a'+i))}
+		mocks[nodes[i].ID] = transfer.NewMock()
+	}
+	destination := func(node *internal.Node) string { return "/srv/" + node.ID + "/app.bin" }
+
+	t.Run("LimitsConcurrency", func(t *testing.T) {
+		var inFlight, peak int32
+		upload := func(node *internal.Node, dest string) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return transfer.UploadPath(mocks[node.ID], local, dest, nil)
+		}
+
+		var results []string
+		err := uploadConcurrently(nodes, 2, destination, upload, func(node *internal.Node, _ time.Time, dest string, err error) {
+			results = append(results, dest)
+		})
+		if err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		if peak > 2 {
+			t.Errorf("Expected at most 2 concurrent uploads, got %d", peak)
+		}
+		if len(results) != len(nodes) {
+			t.Errorf("Expected a result per node, got %d", len(results))
+		}
+		for _, node := range nodes {
+			if data, ok := mocks[node.ID].File(destination(node)); !ok || string(data) != "binary" {
+				t.Errorf("%s: expected uploaded file, got %q", node.ID, data)
+			}
+		}
+	})
+
+	t.Run("ReturnsFirstError", func(t *testing.T) {
+		boom := errors.New("disk full")
+		failed := 0
+		upload := func(node *internal.Node, dest string) error {
+			switch node.ID {
+			case "c":
+				return boom
+			case "e":
+				time.Sleep(50 * time.Millisecond)
+				return errors.New("timeout")
+			}
+			return nil
+		}
+		// onNode 由 uploadConcurrently 串行调用，无需加锁
+		err := uploadConcurrently(nodes, 4, destination, upload, func(_ *internal.Node, _ time.Time, _ string, err error) {
+			if err != nil {
+				failed++
+			}
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("Expected wrapped upload error, got %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "web-c: ") {
+			t.Errorf("Expected the first failure prefixed with its node name, got %v", err)
+		}
+		if failed != 2 {
+			t.Errorf("Expected both failures reported, got %d", failed)
+		}
+	})
+}
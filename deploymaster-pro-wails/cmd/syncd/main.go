@@ -1,17 +1,32 @@
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strings"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/pkg/sftp"
 
 	"deploymaster-pro-wails/internal/ssh"
+	"deploymaster-pro-wails/internal/transfer"
 )
 
-const version = "1.0.0"
+const (
+	version = "1.4.0"
+
+	// 与 internal.Protocol 取值一致
+	scpProtocol  = "SCP"
+	ftpProtocol  = "FTP"
+	ftpsProtocol = "FTPS"
+)
 
 type payload struct {
 	Version    string   `json:"version"`
@@ -28,6 +43,35 @@ type target struct {
 	User       string `json:"user"`
 	Password   string `json:"password"`
 	RemotePath string `json:"remotePath"`
+	Protocol   string `json:"protocol,omitempty"` // SFTP（默认）、SCP 或 FTP/FTPS
+	Jumps      []hop  `json:"jumps,omitempty"`    // 跳板链，按连接顺序排列
+
+	// 中转：Children 非空时本节点收到文件后再由其上的 syncd 向下一层分发；
+	// Relay 为 true 表示本节点不是部署目标，分发完成后清理暂存文件
+	Relay    bool     `json:"relay,omitempty"`
+	Children []target `json:"children,omitempty"`
+}
+
+// hop 跳板机，仅支持密码认证
+type hop struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// exitError 携带进程退出码的错误
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func fail(code int, format string, args ...any) error {
+	return &exitError{code: code, err: fmt.Errorf(format, args...)}
 }
 
 func main() {
@@ -68,41 +112,182 @@ func main() {
 	}
 
 	for _, slave := range req.Slaves {
+		if err := distribute(req, slave); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code := 1
+			if e, ok := err.(*exitError); ok {
+				code = e.code
+			}
+			os.Exit(code)
+		}
+	}
+}
+
+// distribute 将源路径上传到目标节点；目标节点带有下级节点时继续经由它分发
+func distribute(req payload, slave target) error {
+	targetPath := slave.RemotePath
+	if strings.TrimSpace(targetPath) == "" {
+		targetPath = req.RemotePath
+	}
+
+	// FTP 节点只接收文件，不能作为中转节点；允许匿名登录
+	if slave.Protocol == ftpProtocol || slave.Protocol == ftpsProtocol {
 		user := slave.User
 		if strings.TrimSpace(user) == "" {
-			user = "root"
+			user = "anonymous"
 		}
-		if strings.TrimSpace(slave.Password) == "" {
-			fmt.Fprintf(os.Stderr, "missing password for slave %s\n", slave.Name)
-			os.Exit(3)
+		ft, err := transfer.DialFTP(transfer.FTPConfig{
+			Host:     slave.Host,
+			Port:     slave.Port,
+			User:     user,
+			Password: slave.Password,
+			TLS:      slave.Protocol == ftpsProtocol,
+		})
+		if err != nil {
+			return fail(4, "connect slave %s failed: %v", slave.Name, err)
 		}
-
-		targetPath := slave.RemotePath
-		if strings.TrimSpace(targetPath) == "" {
-			targetPath = req.RemotePath
+		defer ft.Close()
+		if err := transfer.UploadPath(ft, req.SourcePath, targetPath, nil); err != nil {
+			return fail(5, "upload to slave %s failed: %v", slave.Name, err)
 		}
+		return nil
+	}
 
-		client := ssh.NewClient(user, slave.Password)
-		if err := client.Connect(slave.Host, slave.Port); err != nil {
-			fmt.Fprintf(os.Stderr, "connect slave %s failed: %v\n", slave.Name, err)
-			os.Exit(4)
-		}
+	user := slave.User
+	if strings.TrimSpace(user) == "" {
+		user = "root"
+	}
+	if strings.TrimSpace(slave.Password) == "" {
+		return fail(3, "missing password for slave %s", slave.Name)
+	}
 
+	client := ssh.NewClient(user, slave.Password)
+	jumps := make([]ssh.JumpHost, 0, len(slave.Jumps))
+	for _, h := range slave.Jumps {
+		jumps = append(jumps, ssh.JumpHost{Client: ssh.NewClient(h.User, h.Password), Host: h.Host, Port: h.Port})
+	}
+	client.SetJumpHosts(jumps...)
+	if err := client.Connect(slave.Host, slave.Port); err != nil {
+		return fail(4, "connect slave %s failed: %v", slave.Name, err)
+	}
+	defer client.Close()
+
+	// put 写入单个远程文件，用于向中转节点复制 syncd
+	var put func(path string, data []byte) error
+	if slave.Protocol == scpProtocol {
+		if err := client.UploadPathSCP(req.SourcePath, targetPath, nil); err != nil {
+			return fail(5, "upload to slave %s failed: %v", slave.Name, err)
+		}
+		put = func(path string, data []byte) error {
+			return client.WriteFileSCP(path, data, 0755)
+		}
+	} else {
 		sftpClient, err := client.NewSFTPClient()
 		if err != nil {
-			_ = client.Close()
-			fmt.Fprintf(os.Stderr, "create sftp for %s failed: %v\n", slave.Name, err)
-			os.Exit(4)
+			return fail(4, "create sftp for %s failed: %v", slave.Name, err)
 		}
+		defer sftpClient.Close()
 
 		if err := ssh.UploadPath(sftpClient, req.SourcePath, targetPath); err != nil {
-			_ = sftpClient.Close()
-			_ = client.Close()
-			fmt.Fprintf(os.Stderr, "upload to slave %s failed: %v\n", slave.Name, err)
-			os.Exit(5)
+			return fail(5, "upload to slave %s failed: %v", slave.Name, err)
+		}
+		put = func(path string, data []byte) error {
+			return writeSFTP(sftpClient, path, data)
+		}
+	}
+
+	if len(slave.Children) == 0 {
+		return nil
+	}
+	if slave.Relay {
+		defer func() {
+			_, _ = client.ExecuteCommand("rm -rf " + shellescape.Quote(targetPath))
+		}()
+	}
+	if err := relay(client, put, slave, targetPath, req.RemotePath); err != nil {
+		return fail(6, "relay through %s failed: %v", slave.Name, err)
+	}
+	return nil
+}
+
+// relay 将自身复制到中转节点并在其上执行，由中转节点向下级节点分发
+// 中转节点需与当前节点的操作系统与架构一致
+func relay(client *ssh.Client, put func(path string, data []byte) error, slave target, sourcePath, remotePath string) error {
+	osName, arch, err := remotePlatform(client)
+	if err != nil {
+		return err
+	}
+	if osName != runtime.GOOS || arch != runtime.GOARCH {
+		return fmt.Errorf("relay is %s/%s, syncd on this hop is %s/%s", osName, arch, runtime.GOOS, runtime.GOARCH)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	bin, err := os.ReadFile(self)
+	if err != nil {
+		return err
+	}
+
+	binPath := fmt.Sprintf("/tmp/deploymaster-syncd-relay-%d", time.Now().UnixNano())
+	if err := put(binPath, bin); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = client.ExecuteCommand("rm -f " + shellescape.Quote(binPath))
+	}()
+	if _, err := client.ExecuteCommand("chmod +x " + shellescape.Quote(binPath)); err != nil {
+		return fmt.Errorf("chmod syncd failed: %w", err)
+	}
+
+	raw, err := json.Marshal(payload{
+		Version:    version,
+		SourcePath: sourcePath,
+		RemotePath: remotePath,
+		Slaves:     slave.Children,
+	})
+	if err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("%s --payload %s", shellescape.Quote(binPath), shellescape.Quote(base64.StdEncoding.EncodeToString(raw)))
+	if output, err := client.ExecuteCommand(cmd); err != nil {
+		if msg := strings.TrimSpace(output); msg != "" {
+			return fmt.Errorf("%s", msg)
 		}
+		return err
+	}
+	return nil
+}
 
-		_ = sftpClient.Close()
-		_ = client.Close()
+func writeSFTP(client *sftp.Client, path string, data []byte) error {
+	dst, err := client.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, bytes.NewReader(data)); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// remotePlatform 返回远程节点的 GOOS/GOARCH 形式的平台标识
+func remotePlatform(client *ssh.Client) (string, string, error) {
+	output, err := client.ExecuteCommand("uname -sm")
+	if err != nil {
+		return "", "", err
+	}
+	fields := strings.Fields(strings.ToLower(output))
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("unexpected uname output %q", strings.TrimSpace(output))
+	}
+	arch := fields[1]
+	switch arch {
+	case "x86_64":
+		arch = "amd64"
+	case "aarch64":
+		arch = "arm64"
 	}
+	return fields[0], arch, nil
 }
@@ -12,8 +12,14 @@ import {
     HasStoredCredential,
     GetCredential,
     TestConnectionWithCredentials,
-    SelectKeyFile
+    SelectKeyFile,
+    GetNodeStatuses,
+    RefreshNodeStatuses,
+    GetHealthSummary,
+    GetNodeHealth,
+    GetNodeMetrics
 } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 import { internal } from '../../wailsjs/go/models';
 import { RemoteServer } from '../types';
 
@@ -21,6 +27,8 @@ import { RemoteServer } from '../types';
 const servers = ref<RemoteServer[]>([]);
 const loading = ref(false);
 const error = ref<string | null>(null);
+// 节点资源采集结果，按节点 ID 索引
+const metrics = ref<Record<string, internal.NodeMetrics>>({});
 
 /**
  * 将 Go 后端模型转换为前端使用的 RemoteServer 类型
@@ -36,9 +44,40 @@ const nodeToServer = (node: internal.Node): RemoteServer => {
         username: node.username,
         authMethod: node.authMethod as any,
         keyPath: node.keyPath,
+        tags: node.tags || {},
+        parentId: node.parentId,
+        jumpHostId: node.jumpHostId,
     };
 };
 
+/**
+ * 将后端探测结果写入对应节点
+ */
+const applyStatus = (nodeId: string, status: internal.NodeStatus) => {
+    const idx = servers.value.findIndex(s => s.id === nodeId);
+    if (idx === -1) return;
+    servers.value[idx] = {
+        ...servers.value[idx],
+        latency: status.latency,
+        delay: status.latency,
+        status: (status.status === 'connected') ? 'connected' : 'disconnected',
+        lastChecked: status.lastChecked ? new Date(status.lastChecked).toLocaleTimeString() : '--',
+    };
+};
+
+/**
+ * 将心跳历史统计写入对应节点
+ */
+const applyHealth = (summary: Record<string, internal.NodeHealth>) => {
+    servers.value = servers.value.map(s => {
+        const health = summary[s.id];
+        return { ...s, uptime: health && health.checks > 0 ? health.uptime : undefined };
+    });
+};
+
+// 一轮心跳会逐个推送节点结果，合并为一次统计刷新
+let healthTimer: ReturnType<typeof setTimeout> | undefined;
+
 export function useNodeService() {
     /**
      * 加载所有节点 - 修正：保留当前已有的状态
@@ -59,7 +98,8 @@ export function useNodeService() {
                         status: existing.status,
                         delay: existing.delay,
                         latency: existing.latency,
-                        lastChecked: existing.lastChecked
+                        lastChecked: existing.lastChecked,
+                        uptime: existing.uptime
                     };
                 }
                 return newS;
@@ -162,17 +202,7 @@ export function useNodeService() {
 
         try {
             const status = await TestNodeConnection(nodeId, username, password);
-            if (serverIdx !== -1) {
-                // 使用 reactive 方式确保更新生效
-                const updatedServer = {
-                    ...servers.value[serverIdx],
-                    latency: status.latency,
-                    delay: status.latency,
-                    status: (status.status === 'connected') ? 'connected' : 'disconnected' as any,
-                    lastChecked: status.lastChecked ? new Date(status.lastChecked).toLocaleTimeString() : '--',
-                };
-                servers.value[serverIdx] = updatedServer;
-            }
+            applyStatus(nodeId, status);
             return status;
         } catch (err: any) {
             if (serverIdx !== -1) {
@@ -289,6 +319,85 @@ export function useNodeService() {
         }
     };
 
+    /**
+     * 加载心跳探测的最新结果
+     */
+    const loadStatuses = async () => {
+        try {
+            const statuses = await GetNodeStatuses();
+            Object.entries(statuses || {}).forEach(([id, status]) => applyStatus(id, status));
+        } catch (err: any) {
+            console.error('加载节点状态失败:', err);
+        }
+    };
+
+    /**
+     * 加载各节点心跳历史的可用率
+     */
+    const loadHealth = async () => {
+        try {
+            applyHealth(await GetHealthSummary() || {});
+        } catch (err: any) {
+            console.error('加载节点可用率失败:', err);
+        }
+    };
+
+    /**
+     * 获取单个节点最近 limit 个心跳样本及统计，用于延迟趋势
+     */
+    const getNodeHealth = async (nodeId: string, limit = 60) => {
+        try {
+            return await GetNodeHealth(nodeId, limit);
+        } catch (err: any) {
+            console.error('加载心跳历史失败:', err);
+            return null;
+        }
+    };
+
+    /**
+     * 获取节点资源数据（负载、内存、磁盘），后端缓存未过期时不会重新采集
+     */
+    const loadMetrics = async (nodeId: string) => {
+        try {
+            const result = await GetNodeMetrics(nodeId);
+            metrics.value = { ...metrics.value, [nodeId]: result };
+            return result;
+        } catch (err: any) {
+            console.error('加载节点资源失败:', err);
+            return null;
+        }
+    };
+
+    /**
+     * 订阅后端定期推送的主控机资源数据，返回取消订阅函数
+     */
+    const subscribeMetrics = () =>
+        EventsOn('node:metrics', (event: internal.NodeMetrics) => {
+            metrics.value = { ...metrics.value, [event.nodeId]: event };
+        });
+
+    /**
+     * 立即对所有节点做一次心跳探测，结果经 node:status 事件更新
+     */
+    const refreshStatuses = async () => {
+        servers.value.forEach(s => { s.status = 'testing'; });
+        try {
+            await RefreshNodeStatuses();
+        } catch (err: any) {
+            console.error('探测节点失败:', err);
+        }
+    };
+
+    /**
+     * 订阅后端心跳推送，返回取消订阅函数
+     */
+    const subscribeStatus = () =>
+        EventsOn('node:status', (event: { nodeId: string; status?: internal.NodeStatus }) => {
+            if (event.status) applyStatus(event.nodeId, event.status);
+            clearTimeout(healthTimer);
+            healthTimer = setTimeout(loadHealth, 1000);
+        });
+
     /**
      * 选择密钥文件
      */
@@ -305,12 +414,20 @@ export function useNodeService() {
         servers,
         loading,
         error,
+        metrics,
+        loadMetrics,
+        subscribeMetrics,
         loadNodes,
         addNode,
         updateNode,
         deleteNode,
         testConnection,
         testAllNodes,
+        loadStatuses,
+        loadHealth,
+        getNodeHealth,
+        refreshStatuses,
+        subscribeStatus,
         getTopology,
         saveCredential,
         saveKeyPassphrase,
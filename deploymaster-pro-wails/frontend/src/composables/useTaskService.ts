@@ -10,11 +10,16 @@ import {
   DeleteTaskTemplate,
   GetTaskRuns,
   GetTaskRunsByTask,
+  GetTaskRunLogs,
+  GetTaskRunLogEntries,
+  SearchRuns,
+  ExportRunReport,
+  RerunExact,
   DeleteTaskRun,
   DeleteTaskRunsByTask,
 } from '../../wailsjs/go/main/App';
 import { internal } from '../../wailsjs/go/models';
-import { DeploymentTask, TaskTemplate, TaskRun } from '../types';
+import { DeploymentTask, TaskTemplate, TaskRun, RunQuery, LogEntry, formatLogEntry, TransferMode } from '../types';
 
 const tasks = ref<DeploymentTask[]>([]);
 const templates = ref<TaskTemplate[]>([]);
@@ -27,7 +32,10 @@ const modelToTask = (task: internal.TaskDefinition): DeploymentTask => ({
   name: task.name,
   svnResourceId: task.svnResourceId,
   masterServerId: task.masterServerId,
+  topologyId: task.topologyId,
   slaveServerIds: task.slaveServerIds || [],
+  slaveSelector: task.slaveSelector,
+  transferMode: (task.transferMode || 'relay') as TransferMode,
   remotePath: task.remotePath,
   slaveRemotePath: task.slaveRemotePath,
   slaveRemotePaths: task.slaveRemotePaths || {},
@@ -45,7 +53,10 @@ const modelToTemplate = (tpl: internal.TaskTemplate): TaskTemplate => ({
   name: tpl.name,
   svnResourceId: tpl.svnResourceId,
   masterServerId: tpl.masterServerId,
+  topologyId: tpl.topologyId,
   slaveServerIds: tpl.slaveServerIds || [],
+  slaveSelector: tpl.slaveSelector,
+  transferMode: (tpl.transferMode || 'relay') as TransferMode,
   remotePath: tpl.remotePath,
   slaveRemotePath: tpl.slaveRemotePath,
   slaveRemotePaths: tpl.slaveRemotePaths || {},
@@ -64,6 +75,11 @@ const modelToRun = (run: internal.TaskRun): TaskRun => ({
   startedAt: run.startedAt,
   finishedAt: run.finishedAt,
   logs: run.logs || [],
+  logCount: run.logCount ?? 0,
+  svnRevision: run.svnRevision,
+  nodeIds: run.nodeIds || [],
+  steps: (run.steps || []) as any,
+  hasSnapshot: Boolean(run.snapshot),
 });
 
 export function useTaskService() {
@@ -213,6 +229,56 @@ export function useTaskService() {
     }
   };
 
+  // 运行历史仅包含摘要，日志按需加载
+  const loadRunLogs = async (runId: string) => {
+    const run = runs.value.find(r => r.id === runId);
+    if (!run) return;
+    try {
+      const entries = ((await GetTaskRunLogEntries(runId)) || []) as LogEntry[];
+      run.entries = entries;
+      // 展示文本由后端按当前语言渲染
+      run.logs = (await GetTaskRunLogs(runId)) || entries.map(formatLogEntry);
+    } catch (err: any) {
+      error.value = `加载运行日志失败: ${err.message || err}`;
+      console.error('加载运行日志失败:', err);
+    }
+  };
+
+  // 按条件检索运行历史（含日志全文），不修改当前 runs 列表
+  const searchRuns = async (query: RunQuery) => {
+    error.value = null;
+    try {
+      const result = await SearchRuns(internal.RunQuery.createFrom({ offset: 0, limit: 0, ...query }));
+      return { runs: (result.runs || []).map(modelToRun), total: result.total };
+    } catch (err: any) {
+      error.value = `检索运行历史失败: ${err.message || err}`;
+      console.error('检索运行历史失败:', err);
+      throw err;
+    }
+  };
+
+  // 导出执行报告，path 为空时弹出保存对话框；返回保存路径，取消时为空
+  const exportRunReport = async (runId: string, format: 'html' | 'markdown' | 'json', path = '') => {
+    try {
+      return await ExportRunReport(runId, format, path);
+    } catch (err: any) {
+      error.value = `导出执行报告失败: ${err.message || err}`;
+      console.error('导出执行报告失败:', err);
+      throw err;
+    }
+  };
+
+  // 按历史运行的配置快照与修订号重新执行，返回新运行 ID
+  const rerunExact = async (runId: string) => {
+    try {
+      return await RerunExact(runId);
+    } catch (err: any) {
+      error.value = `重新执行失败: ${err.message || err}`;
+      console.error('重新执行失败:', err);
+      throw err;
+    }
+  };
+
   const deleteRun = async (runId: string) => {
     loading.value = true;
     try {
@@ -257,6 +323,10 @@ export function useTaskService() {
     deleteTemplate,
     loadRuns,
     loadRunsByTask,
+    loadRunLogs,
+    searchRuns,
+    exportRunReport,
+    rerunExact,
     deleteRun,
     deleteRunsByTask,
   };
@@ -0,0 +1,68 @@
+import {
+  OpenTerminal,
+  WriteTerminal,
+  ResizeTerminal,
+  CloseTerminal,
+} from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { internal } from '../../wailsjs/go/models';
+
+export interface TerminalHandlers {
+  onData: (data: Uint8Array) => void;   // 原始输出字节，可直接交给 xterm.js 的 write
+  onClose: (error?: string) => void;    // 会话结束，error 为空表示正常退出
+}
+
+// terminal:output 携带 data，terminal:closed 转换后携带 closed 与 error
+type TerminalEvent = { sessionId: string; data?: string; closed?: boolean; error?: string };
+
+const decode = (base64: string) => Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+
+/**
+ * 节点交互式终端：打开会话后经 terminal:output / terminal:closed 事件接收输出与结束通知
+ */
+export function useTerminalService() {
+  /**
+   * 打开终端并订阅该会话的事件，返回会话信息与操作函数
+   */
+  const openTerminal = async (nodeId: string, cols: number, rows: number, handlers: TerminalHandlers) => {
+    // 会话打开后后端立即推送输出（如登录提示符），先订阅并暂存，拿到会话 ID 后再转发
+    let sessionId = '';
+    const pending: TerminalEvent[] = [];
+    const dispatch = (event: TerminalEvent) => {
+      if (!sessionId) {
+        pending.push(event);
+        return;
+      }
+      if (event.sessionId !== sessionId) return;
+      if (event.closed) {
+        offOutput();
+        offClosed();
+        handlers.onClose(event.error);
+      } else if (event.data) {
+        handlers.onData(decode(event.data));
+      }
+    };
+    const offOutput = EventsOn('terminal:output', dispatch);
+    const offClosed = EventsOn('terminal:closed', (event: TerminalEvent) => dispatch({ ...event, closed: true }));
+
+    let session: internal.TerminalSession;
+    try {
+      session = await OpenTerminal(nodeId, cols, rows);
+    } catch (err) {
+      offOutput();
+      offClosed();
+      throw err;
+    }
+    sessionId = session.id;
+    pending.splice(0).forEach(dispatch);
+
+    return {
+      session,
+      write: (data: string) => WriteTerminal(session.id, data),
+      resize: (c: number, r: number) => ResizeTerminal(session.id, c, r),
+      close: () => CloseTerminal(session.id).catch(() => { /* 会话可能已结束 */ }),
+    };
+  };
+
+  return { openTerminal };
+}
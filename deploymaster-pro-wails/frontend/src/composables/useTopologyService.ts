@@ -0,0 +1,81 @@
+import { ref } from 'vue';
+import {
+  GetTopologies,
+  AddTopology,
+  UpdateTopology,
+  DeleteTopology,
+} from '../../wailsjs/go/main/App';
+import { internal } from '../../wailsjs/go/models';
+import { Topology } from '../types';
+
+// 全局共享状态
+const topologies = ref<Topology[]>([]);
+const loading = ref(false);
+const error = ref<string | null>(null);
+
+const modelToTopology = (t: internal.Topology): Topology => ({
+  id: t.id,
+  name: t.name,
+  description: t.description,
+  masterId: t.masterId,
+  slaveIds: t.slaveIds || [],
+  slaveSelector: t.slaveSelector,
+  createdAt: t.createdAt,
+  updatedAt: t.updatedAt,
+});
+
+export function useTopologyService() {
+  const loadTopologies = async () => {
+    loading.value = true;
+    error.value = null;
+    try {
+      const list = (await GetTopologies()) || [];
+      topologies.value = list.map(modelToTopology);
+    } catch (err: any) {
+      error.value = `请求加载失败: ${err.message || err}`;
+      console.error('加载部署拓扑失败:', err);
+    } finally {
+      loading.value = false;
+    }
+  };
+
+  const addTopology = async (topology: Partial<Topology>) => {
+    try {
+      await AddTopology(internal.Topology.createFrom(topology));
+      await loadTopologies();
+    } catch (err: any) {
+      error.value = `添加拓扑失败: ${err.message || err}`;
+      throw err;
+    }
+  };
+
+  const updateTopology = async (topology: Partial<Topology>) => {
+    try {
+      await UpdateTopology(internal.Topology.createFrom(topology));
+      await loadTopologies();
+    } catch (err: any) {
+      error.value = `更新拓扑失败: ${err.message || err}`;
+      throw err;
+    }
+  };
+
+  const deleteTopology = async (id: string) => {
+    try {
+      await DeleteTopology(id);
+      await loadTopologies();
+    } catch (err: any) {
+      error.value = `删除拓扑失败: ${err.message || err}`;
+      throw err;
+    }
+  };
+
+  return {
+    topologies,
+    loading,
+    error,
+    loadTopologies,
+    addTopology,
+    updateTopology,
+    deleteTopology,
+  };
+}
@@ -13,19 +13,57 @@ export interface RemoteServer {
   name: string;
   ip: string;
   port: number;
-  protocol: 'SFTP' | 'FTP' | 'SCP';
+  protocol: 'SFTP' | 'FTP' | 'FTPS' | 'SCP';
   isMaster: boolean;
 
   // 认证相关字段
   username?: string;           // SSH用户名
   authMethod?: 'password' | 'key' | 'agent';  // 认证方式
   keyPath?: string;            // SSH私钥路径（仅key模式）
+  tags?: Record<string, string>; // 标签，如 env=prod；值为空表示分组标记
+  parentId?: string;           // 中转父节点，为空时由主控机直接分发
+  jumpHostId?: string;         // SSH 跳板机节点，为空时直连
 
   // 运行时状态
   latency?: number; // 延迟(ms) - 兼容字段
   delay?: number;   // 延迟(ms) - UI显示字段
   status?: 'connected' | 'disconnected' | 'testing';
   lastChecked?: string; // 最后检测时间
+  uptime?: number;      // 心跳历史可用率（百分比），尚无样本时为空
+}
+
+// 标签与 "env=prod, role=web, canary" 形式文本互转
+export const formatTags = (tags?: Record<string, string>) =>
+  Object.entries(tags || {})
+    .sort(([a], [b]) => a.localeCompare(b))
+    .map(([k, v]) => (v ? `${k}=${v}` : k))
+    .join(', ');
+
+export const parseTags = (text: string) => {
+  const tags: Record<string, string> = {};
+  for (const part of text.split(',')) {
+    const item = part.trim();
+    if (!item) continue;
+    const idx = item.indexOf('=');
+    if (idx < 0) tags[item] = '';
+    else tags[item.slice(0, idx).trim()] = item.slice(idx + 1).trim();
+  }
+  return tags;
+};
+
+// 从机分发方式：relay 经主控机 syncd 分发；direct 由客户端并行直传
+export type TransferMode = 'relay' | 'direct';
+
+// 部署拓扑：一台主控机及其从机
+export interface Topology {
+  id: string;
+  name: string;
+  description?: string;
+  masterId: string;
+  slaveIds: string[];
+  slaveSelector?: string;
+  createdAt?: string;
+  updatedAt?: string;
 }
 
 export interface SVNResource {
@@ -53,7 +91,10 @@ export interface DeploymentTask {
   name: string;
   svnResourceId: string;
   masterServerId: string;
+  topologyId?: string; // 引用的部署拓扑，设置后主控机与从机取自拓扑
   slaveServerIds: string[];
+  slaveSelector?: string; // 从机标签选择器，运行时解析
+  transferMode?: TransferMode; // 从机分发方式，默认经主控机中转
   remotePath: string;
   slaveRemotePath?: string;
   slaveRemotePaths?: Record<string, string>;
@@ -71,7 +112,10 @@ export interface TaskTemplate {
   name: string;
   svnResourceId: string;
   masterServerId: string;
+  topologyId?: string; // 引用的部署拓扑，设置后主控机与从机取自拓扑
   slaveServerIds: string[];
+  slaveSelector?: string; // 从机标签选择器，运行时解析
+  transferMode?: TransferMode; // 从机分发方式，默认经主控机中转
   remotePath: string;
   slaveRemotePath?: string;
   slaveRemotePaths?: Record<string, string>;
@@ -90,4 +134,55 @@ export interface TaskRun {
   startedAt: string;
   finishedAt?: string;
   logs: string[];
+  logCount?: number;
+  svnRevision?: string;
+  nodeIds?: string[];
+  steps?: RunStep[];
+  hasSnapshot?: boolean;
+  entries?: LogEntry[];
+}
+
+export type LogLevel = 'info' | 'warn' | 'error' | 'success';
+
+export interface LogEntry {
+  time: string;
+  level: LogLevel;
+  phase?: string;
+  nodeId?: string;
+  message: string;
+  key?: string;
+  args?: string[];
+  fields?: Record<string, string>;
+}
+
+// 与后端 LogEntry.String() 保持一致的展示文本
+export const formatLogEntry = (entry: LogEntry) => {
+  const prefix = entry.level === 'warn' ? '[警告] '
+    : entry.level === 'error' ? '[错误] '
+      : entry.level === 'success' ? '✓ ' : '';
+  return entry.time ? `[${entry.time}] ${prefix}${entry.message}` : `${prefix}${entry.message}`;
+};
+
+export interface RunStep {
+  phase: string;
+  nodeId?: string;
+  name: string;
+  startedAt: string;
+  finishedAt: string;
+  durationMs: number;
+  status: 'success' | 'failed' | 'skipped';
+  output?: string;
+  error?: string;
+}
+
+export interface RunQuery {
+  taskId?: string;
+  statuses?: TaskStatus[];
+  from?: string;
+  to?: string;
+  nodeId?: string;
+  revision?: string;
+  text?: string;
+  offset?: number;
+  limit?: number;
 }
@@ -1,6 +1,7 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {internal} from '../models';
+import {transfer} from '../models';
 
 export function AddNode(arg1:internal.Node):Promise<void>;
 
@@ -10,16 +11,22 @@ export function AddTask(arg1:internal.TaskDefinition):Promise<internal.TaskDefin
 
 export function AddTaskTemplate(arg1:internal.TaskTemplate):Promise<internal.TaskTemplate>;
 
+export function AddTopology(arg1:internal.Topology):Promise<internal.Topology>;
+
 export function BatchTestConnections(arg1:string,arg2:string):Promise<Record<string, internal.NodeStatus>>;
 
 export function CheckoutSVNResource(arg1:string,arg2:string):Promise<string>;
 
+export function CloseTerminal(arg1:string):Promise<void>;
+
 export function ConfirmDialog(arg1:string,arg2:string):Promise<boolean>;
 
 export function DeleteCredential(arg1:string,arg2:string):Promise<void>;
 
 export function DeleteNode(arg1:string):Promise<void>;
 
+export function DeleteRemotePath(arg1:string,arg2:string):Promise<void>;
+
 export function DeleteSVNResource(arg1:string):Promise<void>;
 
 export function DeleteTask(arg1:string):Promise<void>;
@@ -30,16 +37,42 @@ export function DeleteTaskRunsByTask(arg1:string):Promise<void>;
 
 export function DeleteTaskTemplate(arg1:string):Promise<void>;
 
+export function DeleteTopology(arg1:string):Promise<void>;
+
+export function DownloadRemoteFile(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function ExecuteTask(arg1:internal.TaskRunRequest):Promise<void>;
 
+export function ExportRunReport(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function GetActiveRuns():Promise<Array<internal.RunLockState>>;
+
 export function GetCredential(arg1:string,arg2:string):Promise<string>;
 
+export function GetDeploymentStats(arg1:number):Promise<internal.DeploymentStats>;
+
+export function GetHealthSummary():Promise<Record<string, internal.NodeHealth>>;
+
 export function GetNode(arg1:string):Promise<internal.Node>;
 
+export function GetNodeHealth(arg1:string,arg2:number):Promise<internal.NodeHealth>;
+
+export function GetNodeMetrics(arg1:string):Promise<internal.NodeMetrics>;
+
+export function GetNodeStatuses():Promise<Record<string, internal.NodeStatus>>;
+
+export function GetNodeTags():Promise<Record<string, Array<string>>>;
+
 export function GetNodes():Promise<Array<internal.Node>>;
 
 export function GetSVNResources():Promise<Array<internal.SVNResource>>;
 
+export function GetSettings():Promise<internal.AppSettings>;
+
+export function GetTaskRunLogEntries(arg1:string):Promise<Array<internal.LogEntry>>;
+
+export function GetTaskRunLogs(arg1:string):Promise<Array<string>>;
+
 export function GetTaskRuns():Promise<Array<internal.TaskRun>>;
 
 export function GetTaskRunsByTask(arg1:string):Promise<Array<internal.TaskRun>>;
@@ -48,8 +81,12 @@ export function GetTaskTemplates():Promise<Array<internal.TaskTemplate>>;
 
 export function GetTasks():Promise<Array<internal.TaskDefinition>>;
 
+export function GetTopologies():Promise<Array<internal.Topology>>;
+
 export function GetTopology():Promise<internal.TopologyData>;
 
+export function GetTopologyViews():Promise<Array<internal.TopologyData>>;
+
 export function Greet(arg1:string):Promise<string>;
 
 export function HasStoredCredential(arg1:string,arg2:string):Promise<boolean>;
@@ -58,16 +95,44 @@ export function HasStoredKeyPassphrase(arg1:string):Promise<boolean>;
 
 export function HasStoredSVNCredential(arg1:string,arg2:string):Promise<boolean>;
 
+export function ListRemoteDir(arg1:string,arg2:string):Promise<transfer.Listing>;
+
+export function ListTerminals():Promise<Array<internal.TerminalSession>>;
+
+export function MakeRemoteDir(arg1:string,arg2:string):Promise<void>;
+
+export function OpenTerminal(arg1:string,arg2:number,arg3:number):Promise<internal.TerminalSession>;
+
+export function RefreshNodeStatuses():Promise<Record<string, internal.NodeStatus>>;
+
 export function RefreshSVNResource(arg1:string):Promise<internal.SVNResource>;
 
+export function RerunExact(arg1:string):Promise<string>;
+
+export function ResizeTerminal(arg1:string,arg2:number,arg3:number):Promise<void>;
+
+export function RetryRun(arg1:string):Promise<string>;
+
 export function SaveCredential(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<void>;
 
 export function SaveKeyPassphrase(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
 export function SaveSVNCredential(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<void>;
 
+export function SearchRuns(arg1:internal.RunQuery):Promise<internal.RunQueryResult>;
+
 export function SelectKeyFile():Promise<string>;
 
+export function SelectNodes(arg1:string):Promise<Array<internal.Node>>;
+
+export function SetHeartbeatInterval(arg1:number):Promise<void>;
+
+export function SetLanguage(arg1:string):Promise<void>;
+
+export function SetNodeTags(arg1:string,arg2:Record<string, string>):Promise<void>;
+
+export function SetStorageBackend(arg1:string):Promise<void>;
+
 export function ShowMessageDialog(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function TestConnectionWithCredentials(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<internal.NodeStatus>;
@@ -78,8 +143,18 @@ export function TestSVNConnection(arg1:string,arg2:string,arg3:string,arg4:strin
 
 export function UpdateNode(arg1:internal.Node):Promise<void>;
 
+export function UpdateRunRetention(arg1:internal.RunRetention):Promise<void>;
+
+export function UpdateSSHKeepAlive(arg1:internal.SSHKeepAlive):Promise<void>;
+
 export function UpdateSVNResource(arg1:internal.SVNResource):Promise<void>;
 
 export function UpdateTask(arg1:internal.TaskDefinition):Promise<void>;
 
 export function UpdateTaskTemplate(arg1:internal.TaskTemplate):Promise<void>;
+
+export function UpdateTelemetry(arg1:internal.Telemetry):Promise<void>;
+
+export function UpdateTopology(arg1:internal.Topology):Promise<void>;
+
+export function WriteTerminal(arg1:string,arg2:string):Promise<void>;
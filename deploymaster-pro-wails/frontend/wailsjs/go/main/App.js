@@ -18,6 +18,10 @@ export function AddTaskTemplate(arg1) {
   return window['go']['main']['App']['AddTaskTemplate'](arg1);
 }
 
+export function AddTopology(arg1) {
+  return window['go']['main']['App']['AddTopology'](arg1);
+}
+
 export function BatchTestConnections(arg1, arg2) {
   return window['go']['main']['App']['BatchTestConnections'](arg1, arg2);
 }
@@ -26,6 +30,10 @@ export function CheckoutSVNResource(arg1, arg2) {
   return window['go']['main']['App']['CheckoutSVNResource'](arg1, arg2);
 }
 
+export function CloseTerminal(arg1) {
+  return window['go']['main']['App']['CloseTerminal'](arg1);
+}
+
 export function ConfirmDialog(arg1, arg2) {
   return window['go']['main']['App']['ConfirmDialog'](arg1, arg2);
 }
@@ -38,6 +46,10 @@ export function DeleteNode(arg1) {
   return window['go']['main']['App']['DeleteNode'](arg1);
 }
 
+export function DeleteRemotePath(arg1, arg2) {
+  return window['go']['main']['App']['DeleteRemotePath'](arg1, arg2);
+}
+
 export function DeleteSVNResource(arg1) {
   return window['go']['main']['App']['DeleteSVNResource'](arg1);
 }
@@ -58,18 +70,58 @@ export function DeleteTaskTemplate(arg1) {
   return window['go']['main']['App']['DeleteTaskTemplate'](arg1);
 }
 
+export function DeleteTopology(arg1) {
+  return window['go']['main']['App']['DeleteTopology'](arg1);
+}
+
+export function DownloadRemoteFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DownloadRemoteFile'](arg1, arg2, arg3);
+}
+
 export function ExecuteTask(arg1) {
   return window['go']['main']['App']['ExecuteTask'](arg1);
 }
 
+export function ExportRunReport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportRunReport'](arg1, arg2, arg3);
+}
+
+export function GetActiveRuns() {
+  return window['go']['main']['App']['GetActiveRuns']();
+}
+
 export function GetCredential(arg1, arg2) {
   return window['go']['main']['App']['GetCredential'](arg1, arg2);
 }
 
+export function GetDeploymentStats(arg1) {
+  return window['go']['main']['App']['GetDeploymentStats'](arg1);
+}
+
+export function GetHealthSummary() {
+  return window['go']['main']['App']['GetHealthSummary']();
+}
+
 export function GetNode(arg1) {
   return window['go']['main']['App']['GetNode'](arg1);
 }
 
+export function GetNodeHealth(arg1, arg2) {
+  return window['go']['main']['App']['GetNodeHealth'](arg1, arg2);
+}
+
+export function GetNodeMetrics(arg1) {
+  return window['go']['main']['App']['GetNodeMetrics'](arg1);
+}
+
+export function GetNodeStatuses() {
+  return window['go']['main']['App']['GetNodeStatuses']();
+}
+
+export function GetNodeTags() {
+  return window['go']['main']['App']['GetNodeTags']();
+}
+
 export function GetNodes() {
   return window['go']['main']['App']['GetNodes']();
 }
@@ -78,6 +130,18 @@ export function GetSVNResources() {
   return window['go']['main']['App']['GetSVNResources']();
 }
 
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetTaskRunLogEntries(arg1) {
+  return window['go']['main']['App']['GetTaskRunLogEntries'](arg1);
+}
+
+export function GetTaskRunLogs(arg1) {
+  return window['go']['main']['App']['GetTaskRunLogs'](arg1);
+}
+
 export function GetTaskRuns() {
   return window['go']['main']['App']['GetTaskRuns']();
 }
@@ -94,10 +158,18 @@ export function GetTasks() {
   return window['go']['main']['App']['GetTasks']();
 }
 
+export function GetTopologies() {
+  return window['go']['main']['App']['GetTopologies']();
+}
+
 export function GetTopology() {
   return window['go']['main']['App']['GetTopology']();
 }
 
+export function GetTopologyViews() {
+  return window['go']['main']['App']['GetTopologyViews']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
@@ -114,10 +186,42 @@ export function HasStoredSVNCredential(arg1, arg2) {
   return window['go']['main']['App']['HasStoredSVNCredential'](arg1, arg2);
 }
 
+export function ListRemoteDir(arg1, arg2) {
+  return window['go']['main']['App']['ListRemoteDir'](arg1, arg2);
+}
+
+export function ListTerminals() {
+  return window['go']['main']['App']['ListTerminals']();
+}
+
+export function MakeRemoteDir(arg1, arg2) {
+  return window['go']['main']['App']['MakeRemoteDir'](arg1, arg2);
+}
+
+export function OpenTerminal(arg1, arg2, arg3) {
+  return window['go']['main']['App']['OpenTerminal'](arg1, arg2, arg3);
+}
+
+export function RefreshNodeStatuses() {
+  return window['go']['main']['App']['RefreshNodeStatuses']();
+}
+
 export function RefreshSVNResource(arg1) {
   return window['go']['main']['App']['RefreshSVNResource'](arg1);
 }
 
+export function RerunExact(arg1) {
+  return window['go']['main']['App']['RerunExact'](arg1);
+}
+
+export function ResizeTerminal(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ResizeTerminal'](arg1, arg2, arg3);
+}
+
+export function RetryRun(arg1) {
+  return window['go']['main']['App']['RetryRun'](arg1);
+}
+
 export function SaveCredential(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['SaveCredential'](arg1, arg2, arg3, arg4);
 }
@@ -130,10 +234,34 @@ export function SaveSVNCredential(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['SaveSVNCredential'](arg1, arg2, arg3, arg4);
 }
 
+export function SearchRuns(arg1) {
+  return window['go']['main']['App']['SearchRuns'](arg1);
+}
+
 export function SelectKeyFile() {
   return window['go']['main']['App']['SelectKeyFile']();
 }
 
+export function SelectNodes(arg1) {
+  return window['go']['main']['App']['SelectNodes'](arg1);
+}
+
+export function SetHeartbeatInterval(arg1) {
+  return window['go']['main']['App']['SetHeartbeatInterval'](arg1);
+}
+
+export function SetLanguage(arg1) {
+  return window['go']['main']['App']['SetLanguage'](arg1);
+}
+
+export function SetNodeTags(arg1, arg2) {
+  return window['go']['main']['App']['SetNodeTags'](arg1, arg2);
+}
+
+export function SetStorageBackend(arg1) {
+  return window['go']['main']['App']['SetStorageBackend'](arg1);
+}
+
 export function ShowMessageDialog(arg1, arg2, arg3) {
   return window['go']['main']['App']['ShowMessageDialog'](arg1, arg2, arg3);
 }
@@ -154,6 +282,14 @@ export function UpdateNode(arg1) {
   return window['go']['main']['App']['UpdateNode'](arg1);
 }
 
+export function UpdateRunRetention(arg1) {
+  return window['go']['main']['App']['UpdateRunRetention'](arg1);
+}
+
+export function UpdateSSHKeepAlive(arg1) {
+  return window['go']['main']['App']['UpdateSSHKeepAlive'](arg1);
+}
+
 export function UpdateSVNResource(arg1) {
   return window['go']['main']['App']['UpdateSVNResource'](arg1);
 }
@@ -165,3 +301,15 @@ export function UpdateTask(arg1) {
 export function UpdateTaskTemplate(arg1) {
   return window['go']['main']['App']['UpdateTaskTemplate'](arg1);
 }
+
+export function UpdateTelemetry(arg1) {
+  return window['go']['main']['App']['UpdateTelemetry'](arg1);
+}
+
+export function UpdateTopology(arg1) {
+  return window['go']['main']['App']['UpdateTopology'](arg1);
+}
+
+export function WriteTerminal(arg1, arg2) {
+  return window['go']['main']['App']['WriteTerminal'](arg1, arg2);
+}
@@ -1,51 +1,704 @@
 export namespace internal {
 	
+	export class Telemetry {
+	    intervalSeconds: number;
+	    diskPaths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Telemetry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.intervalSeconds = source["intervalSeconds"];
+	        this.diskPaths = source["diskPaths"];
+	    }
+	}
+	export class SSHKeepAlive {
+	    intervalSeconds: number;
+	    maxMissed: number;
+	    idleTimeoutSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SSHKeepAlive(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.intervalSeconds = source["intervalSeconds"];
+	        this.maxMissed = source["maxMissed"];
+	        this.idleTimeoutSeconds = source["idleTimeoutSeconds"];
+	    }
+	}
+	export class RunRetention {
+	    maxRunsPerTask: number;
+	    maxAgeDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunRetention(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxRunsPerTask = source["maxRunsPerTask"];
+	        this.maxAgeDays = source["maxAgeDays"];
+	    }
+	}
+	export class AppSettings {
+	    runRetention: RunRetention;
+	    storageBackend: string;
+	    language: string;
+	    sshKeepAlive: SSHKeepAlive;
+	    heartbeatSeconds: number;
+	    telemetry: Telemetry;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runRetention = this.convertValues(source["runRetention"], RunRetention);
+	        this.storageBackend = source["storageBackend"];
+	        this.language = source["language"];
+	        this.sshKeepAlive = this.convertValues(source["sshKeepAlive"], SSHKeepAlive);
+	        this.heartbeatSeconds = source["heartbeatSeconds"];
+	        this.telemetry = this.convertValues(source["telemetry"], Telemetry);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DailyRunStats {
+	    date: string;
+	    total: number;
+	    success: number;
+	    failed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyRunStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.total = source["total"];
+	        this.success = source["success"];
+	        this.failed = source["failed"];
+	    }
+	}
+	export class FailureCount {
+	    key: string;
+	    label?: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FailureCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.label = source["label"];
+	        this.count = source["count"];
+	    }
+	}
+	export class PhaseDurationStats {
+	    phase: string;
+	    count: number;
+	    meanMs: number;
+	    p95Ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PhaseDurationStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.count = source["count"];
+	        this.meanMs = source["meanMs"];
+	        this.p95Ms = source["p95Ms"];
+	    }
+	}
+	export class TaskRunStats {
+	    taskId: string;
+	    taskName: string;
+	    total: number;
+	    success: number;
+	    failed: number;
+	    successRate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskRunStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.total = source["total"];
+	        this.success = source["success"];
+	        this.failed = source["failed"];
+	        this.successRate = source["successRate"];
+	    }
+	}
+	export class DeploymentStats {
+	    from?: string;
+	    total: number;
+	    success: number;
+	    failed: number;
+	    runsPerDay: DailyRunStats[];
+	    tasks: TaskRunStats[];
+	    phaseDurations: PhaseDurationStats[];
+	    failingNodes: FailureCount[];
+	    failingPhases: FailureCount[];
+	    topErrors: FailureCount[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DeploymentStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.total = source["total"];
+	        this.success = source["success"];
+	        this.failed = source["failed"];
+	        this.runsPerDay = this.convertValues(source["runsPerDay"], DailyRunStats);
+	        this.tasks = this.convertValues(source["tasks"], TaskRunStats);
+	        this.phaseDurations = this.convertValues(source["phaseDurations"], PhaseDurationStats);
+	        this.failingNodes = this.convertValues(source["failingNodes"], FailureCount);
+	        this.failingPhases = this.convertValues(source["failingPhases"], FailureCount);
+	        this.topErrors = this.convertValues(source["topErrors"], FailureCount);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiskUsage {
+	    path: string;
+	    mount: string;
+	    total: number;
+	    free: number;
+	    usedPercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiskUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.mount = source["mount"];
+	        this.total = source["total"];
+	        this.free = source["free"];
+	        this.usedPercent = source["usedPercent"];
+	    }
+	}
+	
+	export class HealthSample {
+	    time: number;
+	    status: string;
+	    latency: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HealthSample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.status = source["status"];
+	        this.latency = source["latency"];
+	    }
+	}
+	export class LogEntry {
+	    time: string;
+	    level: string;
+	    phase?: string;
+	    nodeId?: string;
+	    message: string;
+	    key?: string;
+	    args?: string[];
+	    fields?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.level = source["level"];
+	        this.phase = source["phase"];
+	        this.nodeId = source["nodeId"];
+	        this.message = source["message"];
+	        this.key = source["key"];
+	        this.args = source["args"];
+	        this.fields = source["fields"];
+	    }
+	}
 	export class Node {
 	    id: string;
 	    name: string;
-	    ip: string;
-	    port: number;
-	    protocol: string;
-	    isMaster: boolean;
-	    username?: string;
-	    authMethod?: string;
-	    keyPath?: string;
+	    ip: string;
+	    port: number;
+	    protocol: string;
+	    isMaster: boolean;
+	    tags?: Record<string, string>;
+	    parentId?: string;
+	    jumpHostId?: string;
+	    username?: string;
+	    authMethod?: string;
+	    keyPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Node(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.ip = source["ip"];
+	        this.port = source["port"];
+	        this.protocol = source["protocol"];
+	        this.isMaster = source["isMaster"];
+	        this.tags = source["tags"];
+	        this.parentId = source["parentId"];
+	        this.jumpHostId = source["jumpHostId"];
+	        this.username = source["username"];
+	        this.authMethod = source["authMethod"];
+	        this.keyPath = source["keyPath"];
+	    }
+	}
+	export class NodeHealth {
+	    nodeId: string;
+	    checks: number;
+	    uptime: number;
+	    avgLatency: number;
+	    since: number;
+	    samples?: HealthSample[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NodeHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodeId = source["nodeId"];
+	        this.checks = source["checks"];
+	        this.uptime = source["uptime"];
+	        this.avgLatency = source["avgLatency"];
+	        this.since = source["since"];
+	        this.samples = this.convertValues(source["samples"], HealthSample);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NodeMetrics {
+	    nodeId: string;
+	    collectedAt: number;
+	    cpuCores: number;
+	    load1: number;
+	    load5: number;
+	    load15: number;
+	    memTotal: number;
+	    memAvailable: number;
+	    memUsedPercent: number;
+	    uptimeSeconds: number;
+	    disks: DiskUsage[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NodeMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodeId = source["nodeId"];
+	        this.collectedAt = source["collectedAt"];
+	        this.cpuCores = source["cpuCores"];
+	        this.load1 = source["load1"];
+	        this.load5 = source["load5"];
+	        this.load15 = source["load15"];
+	        this.memTotal = source["memTotal"];
+	        this.memAvailable = source["memAvailable"];
+	        this.memUsedPercent = source["memUsedPercent"];
+	        this.uptimeSeconds = source["uptimeSeconds"];
+	        this.disks = this.convertValues(source["disks"], DiskUsage);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NodeStatus {
+	    latency: number;
+	    lastChecked: string;
+	    status: string;
+	    errorMsg: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NodeStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.latency = source["latency"];
+	        this.lastChecked = source["lastChecked"];
+	        this.status = source["status"];
+	        this.errorMsg = source["errorMsg"];
+	    }
+	}
+	
+	export class RunCheckpoint {
+	    exportPath?: string;
+	    revision?: string;
+	    checksum?: string;
+	    masterId?: string;
+	    masterPath?: string;
+	    syncTargets?: Record<string, string>;
+	    completedPhases?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RunCheckpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exportPath = source["exportPath"];
+	        this.revision = source["revision"];
+	        this.checksum = source["checksum"];
+	        this.masterId = source["masterId"];
+	        this.masterPath = source["masterPath"];
+	        this.syncTargets = source["syncTargets"];
+	        this.completedPhases = source["completedPhases"];
+	    }
+	}
+	export class RunLockState {
+	    runId: string;
+	    taskId: string;
+	    taskName?: string;
+	    keys: string[];
+	    state: string;
+	    since: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunLockState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runId = source["runId"];
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.keys = source["keys"];
+	        this.state = source["state"];
+	        this.since = source["since"];
+	    }
+	}
+	export class RunQuery {
+	    taskId?: string;
+	    statuses?: string[];
+	    from?: string;
+	    to?: string;
+	    nodeId?: string;
+	    revision?: string;
+	    text?: string;
+	    offset: number;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.statuses = source["statuses"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.nodeId = source["nodeId"];
+	        this.revision = source["revision"];
+	        this.text = source["text"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class TaskRunRequest {
+	    taskId: string;
+	    taskName?: string;
+	    svnResourceId: string;
+	    masterServerId: string;
+	    topologyId?: string;
+	    slaveServerIds: string[];
+	    slaveSelector?: string;
+	    remotePath: string;
+	    slaveRemotePath?: string;
+	    slaveRemotePaths?: Record<string, string>;
+	    transferMode?: string;
+	    commands: string[];
+	    concurrency?: string;
+	    svnRevision?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskRunRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.svnResourceId = source["svnResourceId"];
+	        this.masterServerId = source["masterServerId"];
+	        this.topologyId = source["topologyId"];
+	        this.slaveServerIds = source["slaveServerIds"];
+	        this.slaveSelector = source["slaveSelector"];
+	        this.remotePath = source["remotePath"];
+	        this.slaveRemotePath = source["slaveRemotePath"];
+	        this.slaveRemotePaths = source["slaveRemotePaths"];
+	        this.transferMode = source["transferMode"];
+	        this.commands = source["commands"];
+	        this.concurrency = source["concurrency"];
+	        this.svnRevision = source["svnRevision"];
+	    }
+	}
+	export class RunSnapshot {
+	    request: TaskRunRequest;
+	    resourceUrl: string;
+	    resourceType?: string;
+	    revision?: string;
+	    master?: Node;
+	    slaves?: Node[];
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.request = this.convertValues(source["request"], TaskRunRequest);
+	        this.resourceUrl = source["resourceUrl"];
+	        this.resourceType = source["resourceType"];
+	        this.revision = source["revision"];
+	        this.master = this.convertValues(source["master"], Node);
+	        this.slaves = this.convertValues(source["slaves"], Node);
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RunStep {
+	    phase: string;
+	    nodeId?: string;
+	    name: string;
+	    startedAt: string;
+	    finishedAt: string;
+	    durationMs: number;
+	    status: string;
+	    output?: string;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Node(source);
+	        return new RunStep(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
+	        this.phase = source["phase"];
+	        this.nodeId = source["nodeId"];
 	        this.name = source["name"];
-	        this.ip = source["ip"];
-	        this.port = source["port"];
-	        this.protocol = source["protocol"];
-	        this.isMaster = source["isMaster"];
-	        this.username = source["username"];
-	        this.authMethod = source["authMethod"];
-	        this.keyPath = source["keyPath"];
+	        this.startedAt = source["startedAt"];
+	        this.finishedAt = source["finishedAt"];
+	        this.durationMs = source["durationMs"];
+	        this.status = source["status"];
+	        this.output = source["output"];
+	        this.error = source["error"];
 	    }
 	}
-	export class NodeStatus {
-	    latency: number;
-	    lastChecked: string;
+	export class TaskRun {
+	    id: string;
+	    taskId: string;
+	    taskName: string;
 	    status: string;
-	    errorMsg: string;
+	    progress: number;
+	    startedAt: string;
+	    finishedAt?: string;
+	    logs?: string[];
+	    logCount: number;
+	    retryOf?: string;
+	    failedPhase?: string;
+	    error?: string;
+	    errorKey?: string;
+	    checkpoint?: RunCheckpoint;
+	    svnRevision?: string;
+	    nodeIds?: string[];
+	    steps?: RunStep[];
+	    snapshot?: RunSnapshot;
 	
 	    static createFrom(source: any = {}) {
-	        return new NodeStatus(source);
+	        return new TaskRun(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.latency = source["latency"];
-	        this.lastChecked = source["lastChecked"];
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
 	        this.status = source["status"];
-	        this.errorMsg = source["errorMsg"];
+	        this.progress = source["progress"];
+	        this.startedAt = source["startedAt"];
+	        this.finishedAt = source["finishedAt"];
+	        this.logs = source["logs"];
+	        this.logCount = source["logCount"];
+	        this.retryOf = source["retryOf"];
+	        this.failedPhase = source["failedPhase"];
+	        this.error = source["error"];
+	        this.errorKey = source["errorKey"];
+	        this.checkpoint = this.convertValues(source["checkpoint"], RunCheckpoint);
+	        this.svnRevision = source["svnRevision"];
+	        this.nodeIds = source["nodeIds"];
+	        this.steps = this.convertValues(source["steps"], RunStep);
+	        this.snapshot = this.convertValues(source["snapshot"], RunSnapshot);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RunQueryResult {
+	    runs: TaskRun[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunQueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = this.convertValues(source["runs"], TaskRun);
+	        this.total = source["total"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
+	
+	
+	
 	export class SVNResource {
 	    id: string;
 	    url: string;
@@ -99,10 +752,13 @@ export namespace internal {
 	    name: string;
 	    svnResourceId: string;
 	    masterServerId: string;
+	    topologyId?: string;
 	    slaveServerIds: string[];
+	    slaveSelector?: string;
 	    remotePath: string;
 	    slaveRemotePath?: string;
 	    slaveRemotePaths?: Record<string, string>;
+	    transferMode?: string;
 	    commands: string[];
 	    status: string;
 	    progress: number;
@@ -121,10 +777,13 @@ export namespace internal {
 	        this.name = source["name"];
 	        this.svnResourceId = source["svnResourceId"];
 	        this.masterServerId = source["masterServerId"];
+	        this.topologyId = source["topologyId"];
 	        this.slaveServerIds = source["slaveServerIds"];
+	        this.slaveSelector = source["slaveSelector"];
 	        this.remotePath = source["remotePath"];
 	        this.slaveRemotePath = source["slaveRemotePath"];
 	        this.slaveRemotePaths = source["slaveRemotePaths"];
+	        this.transferMode = source["transferMode"];
 	        this.commands = source["commands"];
 	        this.status = source["status"];
 	        this.progress = source["progress"];
@@ -134,95 +793,95 @@ export namespace internal {
 	        this.templateId = source["templateId"];
 	    }
 	}
-	export class TaskRun {
-	    id: string;
-	    taskId: string;
-	    taskName: string;
-	    status: string;
-	    progress: number;
-	    startedAt: string;
-	    finishedAt?: string;
-	    logs: string[];
 	
-	    static createFrom(source: any = {}) {
-	        return new TaskRun(source);
-	    }
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.taskId = source["taskId"];
-	        this.taskName = source["taskName"];
-	        this.status = source["status"];
-	        this.progress = source["progress"];
-	        this.startedAt = source["startedAt"];
-	        this.finishedAt = source["finishedAt"];
-	        this.logs = source["logs"];
-	    }
-	}
-	export class TaskRunRequest {
-	    taskId: string;
-	    taskName?: string;
+	
+	export class TaskTemplate {
+	    id: string;
+	    name: string;
 	    svnResourceId: string;
 	    masterServerId: string;
+	    topologyId?: string;
 	    slaveServerIds: string[];
+	    slaveSelector?: string;
 	    remotePath: string;
 	    slaveRemotePath?: string;
 	    slaveRemotePaths?: Record<string, string>;
+	    transferMode?: string;
 	    commands: string[];
+	    sourceTaskId?: string;
+	    createdAt: string;
+	    updatedAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TaskRunRequest(source);
+	        return new TaskTemplate(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.taskId = source["taskId"];
-	        this.taskName = source["taskName"];
+	        this.id = source["id"];
+	        this.name = source["name"];
 	        this.svnResourceId = source["svnResourceId"];
 	        this.masterServerId = source["masterServerId"];
+	        this.topologyId = source["topologyId"];
 	        this.slaveServerIds = source["slaveServerIds"];
+	        this.slaveSelector = source["slaveSelector"];
 	        this.remotePath = source["remotePath"];
 	        this.slaveRemotePath = source["slaveRemotePath"];
 	        this.slaveRemotePaths = source["slaveRemotePaths"];
+	        this.transferMode = source["transferMode"];
 	        this.commands = source["commands"];
+	        this.sourceTaskId = source["sourceTaskId"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
 	    }
 	}
-	export class TaskTemplate {
+	
+	export class TerminalSession {
+	    id: string;
+	    nodeId: string;
+	    startedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.nodeId = source["nodeId"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	export class Topology {
 	    id: string;
 	    name: string;
-	    svnResourceId: string;
-	    masterServerId: string;
-	    slaveServerIds: string[];
-	    remotePath: string;
-	    slaveRemotePath?: string;
-	    slaveRemotePaths?: Record<string, string>;
-	    commands: string[];
-	    sourceTaskId?: string;
+	    description?: string;
+	    masterId: string;
+	    slaveIds: string[];
+	    slaveSelector?: string;
 	    createdAt: string;
 	    updatedAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TaskTemplate(source);
+	        return new Topology(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.svnResourceId = source["svnResourceId"];
-	        this.masterServerId = source["masterServerId"];
-	        this.slaveServerIds = source["slaveServerIds"];
-	        this.remotePath = source["remotePath"];
-	        this.slaveRemotePath = source["slaveRemotePath"];
-	        this.slaveRemotePaths = source["slaveRemotePaths"];
-	        this.commands = source["commands"];
-	        this.sourceTaskId = source["sourceTaskId"];
+	        this.description = source["description"];
+	        this.masterId = source["masterId"];
+	        this.slaveIds = source["slaveIds"];
+	        this.slaveSelector = source["slaveSelector"];
 	        this.createdAt = source["createdAt"];
 	        this.updatedAt = source["updatedAt"];
 	    }
 	}
 	export class TopologyData {
+	    id?: string;
+	    name?: string;
 	    master?: Node;
 	    slaves: Node[];
 	    total: number;
@@ -233,6 +892,8 @@ export namespace internal {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
 	        this.master = this.convertValues(source["master"], Node);
 	        this.slaves = this.convertValues(source["slaves"], Node);
 	        this.total = source["total"];
@@ -259,3 +920,79 @@ export namespace internal {
 
 }
 
+export namespace transfer {
+	
+	export class FileInfo {
+	    name: string;
+	    size: number;
+	    mode: number;
+	    // Go type: time
+	    modTime: any;
+	    isDir: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.size = source["size"];
+	        this.mode = source["mode"];
+	        this.modTime = this.convertValues(source["modTime"], null);
+	        this.isDir = source["isDir"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Listing {
+	    path: string;
+	    entries: FileInfo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Listing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.entries = this.convertValues(source["entries"], FileInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
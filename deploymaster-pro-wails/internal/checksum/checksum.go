@@ -0,0 +1,119 @@
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+)
+
+// entry 清单条目：相对路径 + 文件内容 sha256
+type entry struct {
+	path string
+	hash string
+}
+
+// Local 计算本地文件或目录的内容校验和
+// 目录按相对路径逐文件计算 sha256，再对排序后的清单整体计算 sha256，
+// 与 RemoteCommand/ParseRemote 在远端得到的结果一致
+func Local(localPath string) (string, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		hash, err := hashFile(localPath)
+		if err != nil {
+			return "", err
+		}
+		return digest([]entry{{path: ".", hash: hash}}), nil
+	}
+
+	entries := make([]entry, 0)
+	err = filepath.WalkDir(localPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(localPath, p)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{path: "./" + filepath.ToSlash(rel), hash: hash})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return digest(entries), nil
+}
+
+// RemoteCommand 生成在远端计算同一校验清单的 shell 命令
+// 优先使用 sha256sum，缺失时回退到 shasum -a 256（macOS）
+func RemoteCommand(remotePath string, isDir bool) string {
+	p := shellescape.Quote(remotePath)
+	if !isDir {
+		return fmt.Sprintf("sha256sum %s 2>/dev/null || shasum -a 256 %s", p, p)
+	}
+	return fmt.Sprintf("cd %s && (find . -type f -exec sha256sum {} + 2>/dev/null || find . -type f -exec shasum -a 256 {} +)", p)
+}
+
+// ParseRemote 解析 RemoteCommand 的输出并计算整体校验和
+func ParseRemote(output string, isDir bool) (string, error) {
+	entries := make([]entry, 0)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		hash, name, ok := strings.Cut(line, "  ")
+		if !ok || len(hash) != sha256.Size*2 {
+			return "", fmt.Errorf("unexpected checksum output: %q", line)
+		}
+		if !isDir {
+			name = "."
+		}
+		entries = append(entries, entry{path: name, hash: strings.ToLower(hash)})
+	}
+	if !isDir && len(entries) != 1 {
+		return "", fmt.Errorf("unexpected checksum output for file: %d entries", len(entries))
+	}
+	return digest(entries), nil
+}
+
+func digest(entries []entry) string {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s  %s\n", e.hash, e.path)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
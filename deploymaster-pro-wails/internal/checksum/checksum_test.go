@@ -0,0 +1,86 @@
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sum(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
+
+func TestLocalMatchesRemote(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"app.jar":          "jar-content",
+		"conf/app.yml":     "port: 8080",
+		"conf/nested/x.sh": "echo ok",
+	}
+	for rel, content := range files {
+		p := filepath.Join(tmpDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir failed: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		local, err := Local(tmpDir)
+		if err != nil {
+			t.Fatalf("Local failed: %v", err)
+		}
+
+		// 模拟 find -exec sha256sum 的无序输出
+		var out strings.Builder
+		for _, rel := range []string{"conf/nested/x.sh", "app.jar", "conf/app.yml"} {
+			fmt.Fprintf(&out, "%s  ./%s\n", sum(files[rel]), rel)
+		}
+		remote, err := ParseRemote(out.String(), true)
+		if err != nil {
+			t.Fatalf("ParseRemote failed: %v", err)
+		}
+		if local != remote {
+			t.Errorf("Expected remote checksum %s, got %s", local, remote)
+		}
+	})
+
+	t.Run("File", func(t *testing.T) {
+		local, err := Local(filepath.Join(tmpDir, "app.jar"))
+		if err != nil {
+			t.Fatalf("Local failed: %v", err)
+		}
+		remote, err := ParseRemote(sum(files["app.jar"])+"  /opt/app/app.jar\n", false)
+		if err != nil {
+			t.Fatalf("ParseRemote failed: %v", err)
+		}
+		if local != remote {
+			t.Errorf("Expected remote checksum %s, got %s", local, remote)
+		}
+	})
+
+	t.Run("ContentChange", func(t *testing.T) {
+		before, _ := Local(tmpDir)
+		if err := os.WriteFile(filepath.Join(tmpDir, "app.jar"), []byte("changed"), 0644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		after, _ := Local(tmpDir)
+		if before == after {
+			t.Error("Expected checksum to change after content change")
+		}
+	})
+
+	t.Run("InvalidOutput", func(t *testing.T) {
+		if _, err := ParseRemote("sha256sum: command not found", true); err == nil {
+			t.Error("Expected error for invalid output")
+		}
+	})
+}
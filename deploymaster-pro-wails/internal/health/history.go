@@ -0,0 +1,171 @@
+package health
+
+import (
+	"deploymaster-pro-wails/internal"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHistoryLimit 每个节点保留的样本数，按默认 60 秒心跳约为 24 小时
+	DefaultHistoryLimit = 1440
+	// historyFlushInterval 两次写盘的最小间隔，避免每轮心跳都重写全部历史
+	historyFlushInterval = 5 * time.Minute
+)
+
+// History 节点心跳历史：每个节点保留最近 limit 个样本，按间隔批量持久化
+type History struct {
+	storage Storage
+	limit   int
+	now     func() time.Time
+
+	mu        sync.Mutex
+	samples   map[string][]internal.HealthSample
+	dirty     bool
+	lastFlush time.Time
+}
+
+// NewHistory 创建心跳历史并加载已有样本，limit <= 0 时使用 DefaultHistoryLimit
+func NewHistory(storage Storage, limit int) (*History, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	histories, err := storage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &History{
+		storage: storage,
+		limit:   limit,
+		now:     time.Now,
+		samples: make(map[string][]internal.HealthSample, len(histories)),
+	}
+	for _, history := range histories {
+		samples := history.Samples
+		if len(samples) > limit {
+			samples = samples[len(samples)-limit:]
+		}
+		h.samples[history.NodeID] = samples
+	}
+	h.lastFlush = h.now()
+	return h, nil
+}
+
+func (h *History) saveLocked() error {
+	ids := make([]string, 0, len(h.samples))
+	for id := range h.samples {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	histories := make([]*internal.NodeHealthHistory, 0, len(ids))
+	for _, id := range ids {
+		histories = append(histories, &internal.NodeHealthHistory{NodeID: id, Samples: h.samples[id]})
+	}
+	if err := h.storage.Save(histories); err != nil {
+		return err
+	}
+	h.dirty = false
+	h.lastFlush = h.now()
+	return nil
+}
+
+// Record 记录一次探测结果，距上次写盘超过 historyFlushInterval 时持久化
+func (h *History) Record(nodeID string, status *internal.NodeStatus) error {
+	if status == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sample := internal.HealthSample{Time: h.now().UnixMilli(), Status: status.Status}
+	if status.Status == internal.StatusConnected {
+		sample.Latency = status.Latency
+	}
+	samples := append(h.samples[nodeID], sample)
+	if len(samples) > h.limit {
+		// 复制到新切片，避免底层数组随记录无限增长
+		samples = append([]internal.HealthSample(nil), samples[len(samples)-h.limit:]...)
+	}
+	h.samples[nodeID] = samples
+	h.dirty = true
+
+	if h.now().Sub(h.lastFlush) < historyFlushInterval {
+		return nil
+	}
+	return h.saveLocked()
+}
+
+// Flush 立即持久化尚未写盘的样本
+func (h *History) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.dirty {
+		return nil
+	}
+	return h.saveLocked()
+}
+
+// Remove 删除节点的全部样本
+func (h *History) Remove(nodeID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.samples[nodeID]; !ok {
+		return nil
+	}
+	delete(h.samples, nodeID)
+	return h.saveLocked()
+}
+
+// Health 返回节点的可用性统计及最近 limit 个样本，limit <= 0 时返回全部样本
+func (h *History) Health(nodeID string, limit int) *internal.NodeHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[nodeID]
+	result := summarize(nodeID, samples)
+	if limit > 0 && len(samples) > limit {
+		samples = samples[len(samples)-limit:]
+	}
+	result.Samples = append([]internal.HealthSample{}, samples...)
+	return result
+}
+
+// Summary 返回所有节点的可用性统计（不含样本）
+func (h *History) Summary() map[string]*internal.NodeHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make(map[string]*internal.NodeHealth, len(h.samples))
+	for id, samples := range h.samples {
+		result[id] = summarize(id, samples)
+	}
+	return result
+}
+
+// summarize 统计可用率与可达时的平均延迟
+func summarize(nodeID string, samples []internal.HealthSample) *internal.NodeHealth {
+	result := &internal.NodeHealth{NodeID: nodeID, Checks: len(samples)}
+	if len(samples) == 0 {
+		return result
+	}
+	result.Since = samples[0].Time
+
+	up, latency := 0, 0
+	for _, s := range samples {
+		if s.Status == internal.StatusConnected {
+			up++
+			latency += s.Latency
+		}
+	}
+	result.Uptime = float64(up) * 100 / float64(len(samples))
+	if up > 0 {
+		result.AvgLatency = latency / up
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"deploymaster-pro-wails/internal"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, err := NewJSONStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newHistory := func(limit int) *History {
+		h, err := NewHistory(storage, limit)
+		if err != nil {
+			t.Fatalf("Failed to create history: %v", err)
+		}
+		h.now = func() time.Time { return clock }
+		h.lastFlush = clock
+		return h
+	}
+	up := &internal.NodeStatus{Status: internal.StatusConnected, Latency: 10}
+	down := &internal.NodeStatus{Status: internal.StatusDisconnected, Latency: 999}
+
+	t.Run("BoundedSamples", func(t *testing.T) {
+		h := newHistory(3)
+		for _, s := range []*internal.NodeStatus{down, up, up, down} {
+			clock = clock.Add(time.Second)
+			_ = h.Record("a", s)
+		}
+		got := h.Health("a", 0)
+		if got.Checks != 3 || len(got.Samples) != 3 {
+			t.Fatalf("Expected 3 samples, got %+v", got)
+		}
+		if got.Samples[2].Status != internal.StatusDisconnected || got.Samples[2].Latency != 0 {
+			t.Errorf("Unreachable sample should carry no latency: %+v", got.Samples[2])
+		}
+		if got.AvgLatency != 10 || got.Uptime < 66 || got.Uptime > 67 {
+			t.Errorf("Unexpected stats %+v", got)
+		}
+		if recent := h.Health("a", 1); len(recent.Samples) != 1 || recent.Checks != 3 {
+			t.Errorf("Expected limit to trim samples only, got %+v", recent)
+		}
+	})
+
+	t.Run("FlushInterval", func(t *testing.T) {
+		h := newHistory(0)
+		_ = h.Record("b", up)
+		if reloaded := newHistory(0); reloaded.Health("b", 0).Checks != 0 {
+			t.Error("Sample should not be written before the flush interval")
+		}
+
+		clock = clock.Add(historyFlushInterval)
+		_ = h.Record("b", down)
+		if got := newHistory(0).Health("b", 0); got.Checks != 2 || got.Uptime != 50 {
+			t.Errorf("Expected flushed samples after interval, got %+v", got)
+		}
+	})
+
+	t.Run("RemoveAndSummary", func(t *testing.T) {
+		h := newHistory(0)
+		_ = h.Record("c", up)
+		if err := h.Remove("c"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		summary := newHistory(0).Summary()
+		if _, ok := summary["c"]; ok {
+			t.Error("Removed node should not appear in summary")
+		}
+		if got := summary["b"]; got == nil || got.Samples != nil {
+			t.Errorf("Summary should include b without samples, got %+v", got)
+		}
+	})
+}
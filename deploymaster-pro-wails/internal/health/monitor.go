@@ -0,0 +1,139 @@
+package health
+
+import (
+	"deploymaster-pro-wails/internal"
+	"sync"
+	"time"
+)
+
+// probeConcurrency 每轮心跳同时探测的节点数
+const probeConcurrency = 8
+
+// Prober 探测单个节点的可达性
+type Prober func(node *internal.Node) *internal.NodeStatus
+
+// Monitor 心跳监控：按固定间隔探测所有节点并记录最新状态
+type Monitor struct {
+	nodes    func() []*internal.Node
+	probe    Prober
+	onStatus func(nodeID string, status *internal.NodeStatus) // 每个节点探测完成后回调，可为 nil
+
+	mu       sync.Mutex
+	statuses map[string]*internal.NodeStatus
+	stop     chan struct{}
+	done     chan struct{}
+	checking sync.Mutex // 同一时间只进行一轮探测
+}
+
+// NewMonitor 创建心跳监控，nodes 返回当前需要探测的节点
+func NewMonitor(nodes func() []*internal.Node, probe Prober, onStatus func(nodeID string, status *internal.NodeStatus)) *Monitor {
+	return &Monitor{
+		nodes:    nodes,
+		probe:    probe,
+		onStatus: onStatus,
+		statuses: make(map[string]*internal.NodeStatus),
+	}
+}
+
+// Start 以 interval 为间隔启动心跳，启动后立即探测一轮；已在运行时按新间隔重启，interval <= 0 时仅停止
+func (m *Monitor) Start(interval time.Duration) {
+	m.Stop()
+	if interval <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	stop, done := make(chan struct{}), make(chan struct{})
+	m.stop, m.done = stop, done
+	m.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			m.CheckAll()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止心跳并等待进行中的一轮探测结束
+func (m *Monitor) Stop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// CheckAll 立即探测所有节点，返回本轮结果；已删除节点的状态随之清除
+func (m *Monitor) CheckAll() map[string]*internal.NodeStatus {
+	m.checking.Lock()
+	defer m.checking.Unlock()
+
+	nodes := m.nodes()
+	results := make(map[string]*internal.NodeStatus, len(nodes))
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	sem := make(chan struct{}, probeConcurrency)
+	for _, node := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(node *internal.Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			status := m.probe(node)
+			mu.Lock()
+			results[node.ID] = status
+			mu.Unlock()
+
+			m.mu.Lock()
+			m.statuses[node.ID] = status
+			m.mu.Unlock()
+			if m.onStatus != nil {
+				m.onStatus(node.ID, status)
+			}
+		}(node)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	for id := range m.statuses {
+		if _, ok := results[id]; !ok {
+			delete(m.statuses, id)
+		}
+	}
+	m.mu.Unlock()
+	return results
+}
+
+// Status 返回节点最近一次的探测结果
+func (m *Monitor) Status(nodeID string) (*internal.NodeStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.statuses[nodeID]
+	return status, ok
+}
+
+// Statuses 返回所有节点最近一次的探测结果
+func (m *Monitor) Statuses() map[string]*internal.NodeStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]*internal.NodeStatus, len(m.statuses))
+	for id, status := range m.statuses {
+		result[id] = status
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"deploymaster-pro-wails/internal"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMonitor(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		nodes = []*internal.Node{{ID: "a"}, {ID: "b"}}
+	)
+	listNodes := func() []*internal.Node {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*internal.Node(nil), nodes...)
+	}
+	var probes atomic.Int32
+	probe := func(n *internal.Node) *internal.NodeStatus {
+		probes.Add(1)
+		if n.ID == "b" {
+			return &internal.NodeStatus{Status: internal.StatusDisconnected}
+		}
+		return &internal.NodeStatus{Status: internal.StatusConnected, Latency: 3}
+	}
+
+	t.Run("CheckAll", func(t *testing.T) {
+		var events atomic.Int32
+		m := NewMonitor(listNodes, probe, func(string, *internal.NodeStatus) { events.Add(1) })
+		results := m.CheckAll()
+		if len(results) != 2 || results["a"].Status != internal.StatusConnected || results["b"].Status != internal.StatusDisconnected {
+			t.Fatalf("Unexpected results: %+v", results)
+		}
+		if events.Load() != 2 {
+			t.Errorf("Expected 2 status callbacks, got %d", events.Load())
+		}
+
+		mu.Lock()
+		nodes = nodes[:1]
+		mu.Unlock()
+		m.CheckAll()
+		if _, ok := m.Status("b"); ok {
+			t.Error("Expected status of removed node to be cleared")
+		}
+		if len(m.Statuses()) != 1 {
+			t.Errorf("Expected 1 status, got %d", len(m.Statuses()))
+		}
+	})
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		m := NewMonitor(listNodes, probe, nil)
+		before := probes.Load()
+		m.Start(10 * time.Millisecond)
+		time.Sleep(55 * time.Millisecond)
+		m.Stop()
+		rounds := probes.Load() - before
+		if rounds < 3 {
+			t.Errorf("Expected several heartbeat rounds, got %d probes", rounds)
+		}
+
+		// 停止后不再探测
+		stopped := probes.Load()
+		time.Sleep(30 * time.Millisecond)
+		if probes.Load() != stopped {
+			t.Error("Expected no probes after Stop")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"deploymaster-pro-wails/internal"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Storage 定义心跳历史存储接口
+type Storage interface {
+	Load() ([]*internal.NodeHealthHistory, error)
+	Save(histories []*internal.NodeHealthHistory) error
+}
+
+// JSONStorage 基于JSON文件的存储实现
+// 存储文件名：health.json，与节点数据放在同一数据目录
+type JSONStorage struct {
+	filePath string
+	mu       sync.RWMutex
+}
+
+// NewJSONStorage 创建心跳历史存储实例
+func NewJSONStorage(dataDir string) (*JSONStorage, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &JSONStorage{
+		filePath: filepath.Join(dataDir, "health.json"),
+	}, nil
+}
+
+// Load 从文件加载心跳历史
+func (s *JSONStorage) Load() ([]*internal.NodeHealthHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := os.Stat(s.filePath); os.IsNotExist(err) {
+		return []*internal.NodeHealthHistory{}, nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection internal.HealthHistoryCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, err
+	}
+	if collection.Nodes == nil {
+		collection.Nodes = []*internal.NodeHealthHistory{}
+	}
+	return collection.Nodes, nil
+}
+
+// Save 保存心跳历史到文件
+// 样本数量较多，不做缩进
+func (s *JSONStorage) Save(histories []*internal.NodeHealthHistory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collection := internal.HealthHistoryCollection{
+		Nodes:     histories,
+		UpdatedAt: time.Now(),
+	}
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+
+	tmpFile := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, s.filePath)
+}
@@ -0,0 +1,167 @@
+package i18n
+
+// enUS 英文消息目录
+// 错误消息与改造前的英文错误文本保持一致，Message.Error() 输出不变
+var enUS = map[string]string{
+	// 日志级别前缀
+	"log.prefix.info":    "",
+	"log.prefix.warn":    "[WARN] ",
+	"log.prefix.error":   "[ERROR] ",
+	"log.prefix.success": "✓ ",
+
+	// 流水线
+	"pipeline.queued":   "Another run of this task or target path is in progress, queued...",
+	"pipeline.dequeued": "Queue cleared, starting.",
+	"pipeline.retry":    "Retrying run %s, previously failed in phase: %s",
+	"pipeline.start":    "Starting deployment pipeline...",
+	"pipeline.success":  "Task succeeded. All nodes are up to date.",
+	"pipeline.sshPool":  "This run opened %v SSH connection(s) and reused them %v time(s)",
+
+	// 执行时间线步骤
+	"step.export":       "Export SVN resource",
+	"step.upload":       "Upload to master",
+	"step.sync":         "Sync slaves",
+	"step.execute":      "Run commands",
+	"step.directUpload": "Direct upload",
+
+	// 导出阶段
+	"export.resourceNotFound": "SVN resource not found, task aborted.",
+	"export.cacheChanged":     "Local export cache is missing or changed, exporting again.",
+	"export.reuse":            "Reusing local export cache (revision %s, checksum verified): %s",
+	"export.connectRevision":  "Connecting to SVN to export revision r%s ...",
+	"export.connectHead":      "Connecting to SVN to export the latest content (HEAD) ...",
+	"export.mkdirFailed":      "Failed to create cache directory: %v",
+	"export.checkoutFailed":   "SVN export failed: %v",
+	"export.checksumFailed":   "Failed to compute export checksum: %v",
+	"export.done":             "SVN export finished. Cache path: %s",
+
+	// 上传阶段
+	"upload.masterNotFound": "Master node not found, task aborted.",
+	"upload.recheck":        "Master copy does not match or cannot be verified, uploading again.",
+	"upload.skip":           "Master copy verified, skipping upload: %s",
+	"upload.start":          "Uploading to master via %s: %s",
+	"upload.failed":         "Upload to master failed: %v",
+	"upload.done":           "Upload to master finished: %s",
+	"upload.progress":       "Uploaded %s / %s",
+
+	// 同步阶段
+	"sync.skip":             "Slaves were already synced by the original run, skipping sync.",
+	"sync.start":            "Master is syncing %v slave(s)...",
+	"sync.prepare":          "Preparing temporary sync service /tmp/deploymaster-syncd on master (version checked, re-uploaded if needed)",
+	"sync.failed":           "Slave sync failed: %v",
+	"sync.failedPermission": "Slave sync failed: %v (check permissions of the slave target directory, or use a writable directory such as /tmp)",
+	"sync.cleaned":          "Temporary sync service finished and /tmp/deploymaster-syncd was removed",
+	"sync.done":             "Master finished syncing slaves.",
+	"sync.passwordAuthOnly": "the master sync service only supports password authentication; switch slave %s to password authentication or use direct upload",
+	"sync.passwordMissing":  "no saved password for slave %s, save the password first",
+	"sync.ftpRelay":         "FTP slave %s cannot relay to other nodes",
+	"sync.jumpUnsupported":  "FTP node %s cannot be reached through a jump host; clear its jump host setting",
+	"sync.directStart":      "Direct upload mode: uploading from this client to %v slave(s) in parallel (%v at a time)...",
+	"sync.directNodeDone":   "Uploaded directly to slave %s: %s",
+	"sync.directNodeFailed": "Direct upload to slave %s failed: %v",
+	"sync.directFailed":     "Direct upload failed: %v",
+	"sync.directDone":       "Direct upload finished.",
+
+	// 主控机同步服务
+	"syncd.unsupportedOS":   "sync service does not support the master OS: only Linux/macOS are supported",
+	"syncd.unsupportedArch": "sync service does not support the master architecture: only amd64/arm64 are supported",
+	"syncd.deployFailed":    "failed to deploy sync service: %v",
+	"syncd.path":            "Sync service path: %s",
+	"syncd.osArch":          "Master platform: %s/%s",
+	"syncd.updated":         "Sync service updated: %s (version=%s, arch=%s)",
+	"syncd.ready":           "Sync service ready: %s (version=%s, arch=%s)",
+	"syncd.checksum":        "Sync service checksum: size=%vB crc32=%s",
+	"syncd.diskUsage":       "/tmp disk: %s free of %s (%s used)",
+	"syncd.targets":         "Sync targets: %s",
+	"syncd.relayTree":       "Relay tree: %s",
+	"syncd.noTimeout":       "Note: timeout is not installed on master, sync runs without a time limit",
+	"syncd.begin":           "Sync started: %s",
+	"syncd.end":             "Sync finished: %s",
+	"syncd.estimate":        "Sync time budget: %vs (for %v slave(s))",
+
+	// 执行阶段
+	"execute.start":      "Starting remote command sequence...",
+	"execute.nodeFailed": "Commands failed on node %s: %v",
+	"execute.nodeDone":   "Commands finished on node %s",
+	"execute.failed":     "Remote commands failed: %v",
+	"execute.skipFTP":    "Node %s uses FTP and cannot run commands, skipped",
+
+	// 资源采集
+	"telemetry.commandFailed": "Failed to run the telemetry command",
+	"telemetry.unsupportedOS": "Telemetry requires a Linux node",
+	"telemetry.parseFailed":   "Failed to parse telemetry output",
+
+	// SSH
+	"ssh.keyPathMissing":     "key authentication selected but no key path provided",
+	"ssh.keyClientFailed":    "failed to create SSH key client: %v",
+	"ssh.agentClientFailed":  "failed to create SSH agent client: %v",
+	"ssh.keyAuthFailed":      "key authentication failed: %v",
+	"ssh.agentAuthFailed":    "agent authentication failed: %v",
+	"ssh.connectFailed":      "connection failed: %v",
+	"ssh.commandFailed":      "command failed: %v",
+	"ssh.unreachable":        "host unreachable: %v",
+	"ssh.bannerFailed":       "failed to read server banner: %v",
+	"ssh.unexpectedBanner":   "unexpected service on port, banner: %s",
+	"ssh.parseKeyFailed":     "parse private key failed",
+	"ssh.readKeyFailed":      "failed to read private key file",
+	"ssh.agentSockMissing":   "SSH_AUTH_SOCK environment variable not set",
+	"ssh.agentConnectFailed": "failed to connect to SSH agent",
+	"ssh.dialFailed":         "ssh dial failed",
+	"ssh.notConnected":       "not connected",
+	"ssh.sessionFailed":      "create session failed",
+	"ssh.executeFailed":      "execute command failed",
+	"ssh.scpRejected":        "remote scp error: %s",
+	"ssh.jumpFailed":         "jump host %s connection failed",
+	"ssh.pingTimeout":        "SSH connection did not respond within %v",
+	"ssh.connectionLost":     "SSH connection lost: %v consecutive keepalive requests went unanswered (interval %v)",
+	"ssh.idleTimeout":        "command produced no output for %v and was aborted",
+	"ssh.ptyFailed":          "request pty failed",
+	"ssh.shellFailed":        "start remote shell failed",
+
+	// SVN
+	"svn.clientNotFound":     "svn client not found",
+	"svn.urlEmpty":           "svn url is empty",
+	"svn.destEmpty":          "export destination is empty",
+	"svn.infoFailed":         "svn info failed: %s",
+	"svn.emptyRevision":      "svn info returned empty revision",
+	"svn.exportFailed":       "svn export failed: %s",
+	"svn.catFailed":          "svn cat failed: %s",
+	"svn.connectionOk":       "SVN connection OK",
+	"svn.notInstalledTitle":  "SVN client not installed",
+	"svn.notInstalledDetail": "The svn command line client was not found. Install SVN first (e.g. xcode-select --install or brew install svn).",
+
+	// 远程文件浏览
+	"remote.downloadTitle": "Download remote file",
+
+	// 执行报告
+	"report.title":       "Execution report: %s",
+	"report.item":        "Item",
+	"report.value":       "Value",
+	"report.runID":       "Run ID",
+	"report.status":      "Status",
+	"report.failedPhase": "Failed phase",
+	"report.startedAt":   "Started at",
+	"report.finishedAt":  "Finished at",
+	"report.duration":    "Duration",
+	"report.retryOf":     "Retry of",
+	"report.svnURL":      "SVN URL",
+	"report.revision":    "Revision",
+	"report.checksum":    "Checksum (sha256)",
+	"report.generatedAt": "Generated at",
+	"report.config":      "Task configuration",
+	"report.master":      "Master node",
+	"report.slaves":      "Slaves",
+	"report.masterPath":  "Master path",
+	"report.slavePath":   "Slave path",
+	"report.timeline":    "Timeline",
+	"report.phase":       "Phase",
+	"report.node":        "Node",
+	"report.step":        "Step",
+	"report.start":       "Start",
+	"report.result":      "Result",
+	"report.logs":        "Run logs",
+	"report.local":       "Local",
+	"report.stepError":   "%s: %s",
+	"report.exportTitle": "Export execution report",
+	"report.fileFilter":  "Execution report (*%s)",
+}
@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Lang 界面与日志语言
+type Lang string
+
+const (
+	LangZH Lang = "zh-CN"
+	LangEN Lang = "en-US"
+)
+
+// Default 默认语言
+const Default = LangZH
+
+var catalogs = map[Lang]map[string]string{
+	LangZH: zhCN,
+	LangEN: enUS,
+}
+
+// Supported 返回支持的语言
+func Supported() []Lang {
+	return []Lang{LangZH, LangEN}
+}
+
+// Normalize 规范化语言标识，如 "en"、"en_US" 归一为 en-US；无法识别时返回默认语言
+func Normalize(value string) Lang {
+	v := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "_", "-"))
+	switch {
+	case strings.HasPrefix(v, "en"):
+		return LangEN
+	case strings.HasPrefix(v, "zh"):
+		return LangZH
+	}
+	return Default
+}
+
+// T 按语言渲染消息，args 依次填入模板
+// 缺少翻译时回退到默认语言，仍缺失时返回 key 本身
+func T(lang Lang, key string, args ...any) string {
+	tpl, ok := catalogs[lang][key]
+	if !ok {
+		if tpl, ok = catalogs[Default][key]; !ok {
+			tpl = key
+		}
+	}
+	if len(args) == 0 {
+		return tpl
+	}
+	return fmt.Sprintf(tpl, args...)
+}
+
+// Message 可本地化的消息，同时实现 error 接口
+// Error() 固定输出英文，保持与其他错误一致；展示给用户时通过 Localizer 渲染
+type Message struct {
+	Key  string
+	Args []any
+	Err  error // 被包装的底层错误，渲染为 "消息: 底层错误"
+}
+
+// New 创建消息
+func New(key string, args ...any) *Message {
+	return &Message{Key: key, Args: args}
+}
+
+// Wrap 包装底层错误，err 为 nil 时返回 nil
+func Wrap(err error, key string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &Message{Key: key, Args: args, Err: err}
+}
+
+func (m *Message) Error() string {
+	return m.render(LangEN)
+}
+
+func (m *Message) Unwrap() error {
+	return m.Err
+}
+
+func (m *Message) render(lang Lang) string {
+	args := make([]any, len(m.Args))
+	for i, arg := range m.Args {
+		if err, ok := arg.(error); ok {
+			arg = errorText(lang, err)
+		}
+		args[i] = arg
+	}
+	text := T(lang, m.Key, args...)
+	if m.Err != nil {
+		text += ": " + errorText(lang, m.Err)
+	}
+	return text
+}
+
+// Strings 渲染参数为字符串，用于结构化日志持久化
+func (m *Message) Strings(lang Lang) []string {
+	result := make([]string, len(m.Args))
+	for i, arg := range m.Args {
+		if err, ok := arg.(error); ok {
+			result[i] = errorText(lang, err)
+			continue
+		}
+		result[i] = fmt.Sprint(arg)
+	}
+	return result
+}
+
+func errorText(lang Lang, err error) string {
+	var msg *Message
+	if errors.As(err, &msg) && msg == err {
+		return msg.render(lang)
+	}
+	// 外层为普通包装错误时保留其文本
+	return err.Error()
+}
+
+// Localizer 按当前语言渲染消息，语言可在运行时切换
+type Localizer struct {
+	lang atomic.Value
+}
+
+// NewLocalizer 创建本地化器
+func NewLocalizer(lang Lang) *Localizer {
+	l := &Localizer{}
+	l.SetLang(lang)
+	return l
+}
+
+// SetLang 切换语言
+func (l *Localizer) SetLang(lang Lang) {
+	l.lang.Store(Normalize(string(lang)))
+}
+
+// Lang 返回当前语言，nil 接收者返回默认语言
+func (l *Localizer) Lang() Lang {
+	if l == nil {
+		return Default
+	}
+	if lang, ok := l.lang.Load().(Lang); ok {
+		return lang
+	}
+	return Default
+}
+
+// T 按当前语言渲染消息
+func (l *Localizer) T(key string, args ...any) string {
+	return T(l.Lang(), key, args...)
+}
+
+// Error 按当前语言渲染错误；非本地化错误原样输出
+func (l *Localizer) Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	return errorText(l.Lang(), err)
+}
+
+// Render 渲染消息
+func (l *Localizer) Render(m *Message) string {
+	return m.render(l.Lang())
+}
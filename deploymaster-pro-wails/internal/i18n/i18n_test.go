@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[a-zA-Z%]`)
+
+func TestCatalogs(t *testing.T) {
+	t.Run("SameKeys", func(t *testing.T) {
+		for key := range zhCN {
+			if _, ok := enUS[key]; !ok {
+				t.Errorf("Key %q missing in en-US catalog", key)
+			}
+		}
+		for key := range enUS {
+			if _, ok := zhCN[key]; !ok {
+				t.Errorf("Key %q missing in zh-CN catalog", key)
+			}
+		}
+	})
+
+	t.Run("Verbs", func(t *testing.T) {
+		// 参数持久化为字符串后重新渲染，只允许 %s / %v
+		for lang, catalog := range catalogs {
+			for key, tpl := range catalog {
+				verbs := verbPattern.FindAllString(tpl, -1)
+				for _, v := range verbs {
+					if v != "%s" && v != "%v" {
+						t.Errorf("%s %q uses unsupported verb %s", lang, key, v)
+					}
+				}
+				if other := verbPattern.FindAllString(catalogs[Default][key], -1); len(other) != len(verbs) {
+					t.Errorf("%s %q has %d verbs, default catalog has %d", lang, key, len(verbs), len(other))
+				}
+			}
+		}
+	})
+}
+
+func TestT(t *testing.T) {
+	if got := T(LangZH, "upload.done", "/opt/app"); got != "主控机资源上传完成：/opt/app" {
+		t.Errorf("Unexpected zh message: %q", got)
+	}
+	if got := T(LangEN, "sync.start", "3"); got != "Master is syncing 3 slave(s)..." {
+		t.Errorf("Unexpected en message: %q", got)
+	}
+	if got := T(LangEN, "no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected key fallback, got %q", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]Lang{
+		"en":    LangEN,
+		"en_US": LangEN,
+		"zh-CN": LangZH,
+		"zh-tw": LangZH,
+		"":      Default,
+		"fr":    Default,
+	}
+	for input, want := range cases {
+		if got := Normalize(input); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	t.Run("ErrorIsEnglish", func(t *testing.T) {
+		err := Wrap(cause, "ssh.dialFailed")
+		if err.Error() != "ssh dial failed: connection refused" {
+			t.Errorf("Unexpected error text: %q", err.Error())
+		}
+		if !errors.Is(err, cause) {
+			t.Error("Expected wrapped error to unwrap to cause")
+		}
+		if Wrap(nil, "ssh.dialFailed") != nil {
+			t.Error("Expected Wrap(nil) to return nil")
+		}
+	})
+
+	t.Run("Localizer", func(t *testing.T) {
+		loc := NewLocalizer(LangZH)
+		err := New("sync.failed", Wrap(cause, "ssh.dialFailed"))
+		if got := loc.Error(err); got != "从机同步失败：SSH 连接失败: connection refused" {
+			t.Errorf("Unexpected zh error: %q", got)
+		}
+
+		loc.SetLang(LangEN)
+		if got := loc.Error(err); got != "Slave sync failed: ssh dial failed: connection refused" {
+			t.Errorf("Unexpected en error: %q", got)
+		}
+		if got := loc.Error(fmt.Errorf("plain")); got != "plain" {
+			t.Errorf("Expected plain error unchanged, got %q", got)
+		}
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		msg := New("execute.nodeFailed", "web-1", New("ssh.notConnected"))
+		args := msg.Strings(LangZH)
+		if len(args) != 2 || args[1] != "SSH 未连接" {
+			t.Errorf("Unexpected args: %q", args)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package i18n
+
+// zhCN 简体中文消息目录
+// 模板参数只使用 %s / %v，保证持久化为字符串后仍可重新渲染
+var zhCN = map[string]string{
+	// 日志级别前缀
+	"log.prefix.info":    "",
+	"log.prefix.warn":    "[警告] ",
+	"log.prefix.error":   "[错误] ",
+	"log.prefix.success": "✓ ",
+
+	// 流水线
+	"pipeline.queued":   "同一任务或目标路径正在执行，已排队等待...",
+	"pipeline.dequeued": "排队结束，开始执行。",
+	"pipeline.retry":    "重试运行 %s，原失败阶段：%s",
+	"pipeline.start":    "启动自动化分发流水线...",
+	"pipeline.success":  "任务执行成功。所有节点已同步至最新状态。",
+	"pipeline.sshPool":  "本次运行建立 %v 个 SSH 连接，复用 %v 次",
+
+	// 执行时间线步骤
+	"step.export":       "导出 SVN 资源",
+	"step.upload":       "上传至主控机",
+	"step.sync":         "同步从机",
+	"step.execute":      "执行命令",
+	"step.directUpload": "客户端直传",
+
+	// 导出阶段
+	"export.resourceNotFound": "未找到 SVN 资源，任务终止。",
+	"export.cacheChanged":     "本地导出缓存缺失或已变更，重新导出 SVN 资源。",
+	"export.reuse":            "复用本地导出缓存（修订号 %s，校验一致）：%s",
+	"export.connectRevision":  "正在建立 SVN 连接，准备拉取指定修订号 r%s ...",
+	"export.connectHead":      "正在建立 SVN 连接，准备拉取最新内容 (HEAD) ...",
+	"export.mkdirFailed":      "创建缓存目录失败：%v",
+	"export.checkoutFailed":   "SVN 检出失败：%v",
+	"export.checksumFailed":   "计算导出内容校验和失败：%v",
+	"export.done":             "SVN 资源检出完成。缓存路径: %s",
+
+	// 上传阶段
+	"upload.masterNotFound": "未找到主控节点，任务终止。",
+	"upload.recheck":        "主控机资源校验不一致或无法校验，重新上传。",
+	"upload.skip":           "主控机资源校验一致，跳过上传：%s",
+	"upload.start":          "正在通过 %s 上传资源至主控机: %s",
+	"upload.failed":         "上传至主控机失败：%v",
+	"upload.done":           "主控机资源上传完成：%s",
+	"upload.progress":       "已上传 %s / %s",
+
+	// 同步阶段
+	"sync.skip":             "原运行已完成从机同步，跳过同步阶段。",
+	"sync.start":            "主控机开始同步 %v 台从机...",
+	"sync.prepare":          "准备主控机临时同步服务 /tmp/deploymaster-syncd（自动校验版本，必要时覆盖上传）",
+	"sync.failed":           "从机同步失败：%v",
+	"sync.failedPermission": "从机同步失败：%v（请检查从机目标目录权限，或改用可写目录如 /tmp）",
+	"sync.cleaned":          "临时同步服务执行完成，已清理 /tmp/deploymaster-syncd",
+	"sync.done":             "主控机同步从机完成。",
+	"sync.passwordAuthOnly": "主控机同步服务仅支持密码认证，从机 %s 请改为密码认证或改用客户端直传模式",
+	"sync.passwordMissing":  "未找到从机 %s 的密码，请先保存密码",
+	"sync.ftpRelay":         "FTP 从机 %s 不能作为中转节点",
+	"sync.jumpUnsupported":  "FTP 节点 %s 不支持经跳板机连接，请清除其跳板机设置",
+	"sync.directStart":      "客户端直传模式：由本机并行上传到 %v 台从机（并发 %v）...",
+	"sync.directNodeDone":   "已直传至从机 %s：%s",
+	"sync.directNodeFailed": "直传至从机 %s 失败：%v",
+	"sync.directFailed":     "客户端直传失败：%v",
+	"sync.directDone":       "客户端直传完成。",
+
+	// 主控机同步服务
+	"syncd.unsupportedOS":   "主控机系统暂不支持同步服务：仅支持 Linux/macOS",
+	"syncd.unsupportedArch": "主控机架构暂不支持同步服务：仅支持 amd64/arm64",
+	"syncd.deployFailed":    "部署同步服务失败：%v",
+	"syncd.path":            "同步服务路径：%s",
+	"syncd.osArch":          "主控机系统检测：%s/%s",
+	"syncd.updated":         "同步服务已更新：%s (version=%s, arch=%s)",
+	"syncd.ready":           "同步服务已就绪：%s (version=%s, arch=%s)",
+	"syncd.checksum":        "同步服务校验：size=%vB crc32=%s",
+	"syncd.diskUsage":       "/tmp 磁盘可用 %s / 共 %s（已用 %s）",
+	"syncd.targets":         "同步目标从机：%s",
+	"syncd.relayTree":       "中转分发路径：%s",
+	"syncd.noTimeout":       "注意：主控机未安装 timeout，无法设置同步超时保护",
+	"syncd.begin":           "同步执行开始：%s",
+	"syncd.end":             "同步执行结束：%s",
+	"syncd.estimate":        "同步耗时预估：%vs（按 %v 台从机计算）",
+
+	// 执行阶段
+	"execute.start":      "正在启动远程自定义脚本执行序列...",
+	"execute.nodeFailed": "节点 %s 命令执行失败：%v",
+	"execute.nodeDone":   "节点 %s 命令执行完成",
+	"execute.failed":     "远程脚本执行失败：%v",
+	"execute.skipFTP":    "节点 %s 使用 FTP 协议，无法执行命令，已跳过",
+
+	// 资源采集
+	"telemetry.commandFailed": "资源采集命令执行失败",
+	"telemetry.unsupportedOS": "资源采集仅支持 Linux 节点",
+	"telemetry.parseFailed":   "无法解析资源采集输出",
+
+	// SSH
+	"ssh.keyPathMissing":     "密钥认证模式但未提供密钥路径",
+	"ssh.keyClientFailed":    "创建SSH密钥客户端失败: %v",
+	"ssh.agentClientFailed":  "创建SSH Agent客户端失败: %v",
+	"ssh.keyAuthFailed":      "密钥认证失败: %v",
+	"ssh.agentAuthFailed":    "Agent认证失败: %v",
+	"ssh.connectFailed":      "连接失败: %v",
+	"ssh.commandFailed":      "命令执行失败: %v",
+	"ssh.unreachable":        "连接不可达: %v",
+	"ssh.bannerFailed":       "读取服务端标识失败: %v",
+	"ssh.unexpectedBanner":   "端口上不是预期的服务，应答: %s",
+	"ssh.parseKeyFailed":     "解析私钥失败",
+	"ssh.readKeyFailed":      "读取私钥文件失败",
+	"ssh.agentSockMissing":   "未设置 SSH_AUTH_SOCK 环境变量",
+	"ssh.agentConnectFailed": "连接 SSH Agent 失败",
+	"ssh.dialFailed":         "SSH 连接失败",
+	"ssh.notConnected":       "SSH 未连接",
+	"ssh.sessionFailed":      "创建 SSH 会话失败",
+	"ssh.executeFailed":      "命令执行失败",
+	"ssh.scpRejected":        "远端 scp 错误：%s",
+	"ssh.jumpFailed":         "跳板机 %s 连接失败",
+	"ssh.pingTimeout":        "SSH 连接在 %v 内无响应",
+	"ssh.connectionLost":     "SSH 连接已断开：连续 %v 次保活请求未响应（间隔 %v）",
+	"ssh.idleTimeout":        "命令在 %v 内无任何输出，已中止",
+	"ssh.ptyFailed":          "申请伪终端失败",
+	"ssh.shellFailed":        "启动远程 shell 失败",
+
+	// SVN
+	"svn.clientNotFound":     "未找到 svn 命令行客户端",
+	"svn.urlEmpty":           "SVN 地址为空",
+	"svn.destEmpty":          "导出目标路径为空",
+	"svn.infoFailed":         "svn info 执行失败: %s",
+	"svn.emptyRevision":      "svn info 未返回修订号",
+	"svn.exportFailed":       "svn export 执行失败: %s",
+	"svn.catFailed":          "svn cat 执行失败: %s",
+	"svn.connectionOk":       "SVN 连接正常",
+	"svn.notInstalledTitle":  "SVN 客户端未安装",
+	"svn.notInstalledDetail": "未检测到 svn 命令行客户端。请先安装 SVN（如：xcode-select --install 或 brew install svn）。",
+
+	// 远程文件浏览
+	"remote.downloadTitle": "下载远程文件",
+
+	// 执行报告
+	"report.title":       "执行报告：%s",
+	"report.item":        "项目",
+	"report.value":       "内容",
+	"report.runID":       "运行 ID",
+	"report.status":      "状态",
+	"report.failedPhase": "失败阶段",
+	"report.startedAt":   "开始时间",
+	"report.finishedAt":  "结束时间",
+	"report.duration":    "耗时",
+	"report.retryOf":     "重试来源",
+	"report.svnURL":      "SVN 地址",
+	"report.revision":    "修订号",
+	"report.checksum":    "校验和 (sha256)",
+	"report.generatedAt": "生成时间",
+	"report.config":      "任务配置",
+	"report.master":      "主控节点",
+	"report.slaves":      "从机",
+	"report.masterPath":  "主控机路径",
+	"report.slavePath":   "从机路径",
+	"report.timeline":    "执行时间线",
+	"report.phase":       "阶段",
+	"report.node":        "节点",
+	"report.step":        "步骤",
+	"report.start":       "开始",
+	"report.result":      "结果",
+	"report.logs":        "运行日志",
+	"report.local":       "本地",
+	"report.stepError":   "%s：%s",
+	"report.exportTitle": "导出执行报告",
+	"report.fileFilter":  "执行报告 (*%s)",
+}
@@ -9,8 +9,14 @@ const (
 	ProtocolSFTP Protocol = "SFTP"
 	ProtocolSCP  Protocol = "SCP"
 	ProtocolFTP  Protocol = "FTP"
+	ProtocolFTPS Protocol = "FTPS" // 显式 TLS（AUTH TLS）的 FTP
 )
 
+// IsFTP 是否为 FTP/FTPS 协议；此类节点只能接收文件，不支持远程命令与中转分发
+func (p Protocol) IsFTP() bool {
+	return p == ProtocolFTP || p == ProtocolFTPS
+}
+
 // ConnectionStatus 定义节点连接状态
 type ConnectionStatus string
 
@@ -39,6 +45,16 @@ type Node struct {
 	Protocol Protocol `json:"protocol"` // 通信协议
 	IsMaster bool     `json:"isMaster"` // 是否为主控节点
 
+	// 标签，如 env=prod、role=web；值为空的标签作为分组标记使用
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// 中转父节点 ID：为空时由主控机直接分发；设置后文件经父节点中转，
+	// 可逐级构成 主控 → 区域中转 → 服务器 的多层分发树
+	ParentID string `json:"parentId,omitempty"`
+
+	// 跳板机节点 ID：设置后 SSH 连接经该节点转发；跳板机自身也可设置跳板机，逐跳构成跳板链
+	JumpHostID string `json:"jumpHostId,omitempty"`
+
 	// 认证相关字段
 	Username   string     `json:"username,omitempty"`   // SSH用户名
 	AuthMethod AuthMethod `json:"authMethod,omitempty"` // 认证方式 ("password", "key", "agent")
@@ -59,11 +75,110 @@ type NodeStatus struct {
 	ErrorMsg    string           `json:"errorMsg"`    // 错误信息（如果有）
 }
 
+// NodeStatusEvent 心跳探测结果事件（node:status）
+type NodeStatusEvent struct {
+	NodeID string      `json:"nodeId"`
+	Status *NodeStatus `json:"status"`
+}
+
+// HealthSample 单次心跳探测样本
+type HealthSample struct {
+	Time    int64            `json:"time"`    // 探测时间（Unix 毫秒）
+	Status  ConnectionStatus `json:"status"`  // 探测结果
+	Latency int              `json:"latency"` // 延迟(ms)，不可达时为 0
+}
+
+// NodeHealthHistory 节点心跳样本序列，按时间升序
+type NodeHealthHistory struct {
+	NodeID  string         `json:"nodeId"`
+	Samples []HealthSample `json:"samples"`
+}
+
+// HealthHistoryCollection 心跳历史集合，用于持久化存储
+type HealthHistoryCollection struct {
+	Nodes     []*NodeHealthHistory `json:"nodes"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+// NodeHealth 节点在已保留样本范围内的可用性统计
+type NodeHealth struct {
+	NodeID     string         `json:"nodeId"`
+	Checks     int            `json:"checks"`            // 探测次数
+	Uptime     float64        `json:"uptime"`            // 可用率（百分比）
+	AvgLatency int            `json:"avgLatency"`        // 可达时的平均延迟(ms)
+	Since      int64          `json:"since"`             // 最早样本时间（Unix 毫秒）
+	Samples    []HealthSample `json:"samples,omitempty"` // 最近的样本，仅查询单个节点时返回
+}
+
+// DiskUsage 远程路径所在文件系统的容量
+type DiskUsage struct {
+	Path        string  `json:"path"`        // 配置的路径
+	Mount       string  `json:"mount"`       // 所在挂载点
+	Total       int64   `json:"total"`       // 总容量（字节）
+	Free        int64   `json:"free"`        // 可用容量（字节）
+	UsedPercent float64 `json:"usedPercent"` // 已用百分比
+}
+
+// NodeMetrics 节点资源遥测数据（node:metrics）
+type NodeMetrics struct {
+	NodeID         string      `json:"nodeId"`
+	CollectedAt    int64       `json:"collectedAt"`     // 采集时间（Unix 毫秒）
+	CPUCores       int         `json:"cpuCores"`        // 逻辑 CPU 数
+	Load1          float64     `json:"load1"`           // 1 分钟平均负载
+	Load5          float64     `json:"load5"`           // 5 分钟平均负载
+	Load15         float64     `json:"load15"`          // 15 分钟平均负载
+	MemTotal       int64       `json:"memTotal"`        // 内存总量（字节）
+	MemAvailable   int64       `json:"memAvailable"`    // 可用内存（字节）
+	MemUsedPercent float64     `json:"memUsedPercent"`  // 内存已用百分比
+	UptimeSeconds  int64       `json:"uptimeSeconds"`   // 系统运行时长
+	Disks          []DiskUsage `json:"disks"`           // 按配置路径顺序，路径不存在时略过
+	Error          string      `json:"error,omitempty"` // 采集失败原因，此时其余字段为空
+}
+
+// TerminalSession 节点上的交互式终端会话
+type TerminalSession struct {
+	ID        string `json:"id"`
+	NodeID    string `json:"nodeId"`
+	StartedAt string `json:"startedAt"`
+}
+
+// TerminalOutputEvent 终端输出事件（terminal:output），Data 为 base64 编码的原始字节
+type TerminalOutputEvent struct {
+	SessionID string `json:"sessionId"`
+	Data      string `json:"data"`
+}
+
+// TerminalClosedEvent 终端结束事件（terminal:closed），Error 为空表示正常退出
+type TerminalClosedEvent struct {
+	SessionID string `json:"sessionId"`
+	Error     string `json:"error,omitempty"`
+}
+
 // TopologyData 定义拓扑结构数据，用于前端可视化
 type TopologyData struct {
-	Master *Node   `json:"master"` // 主控节点
-	Slaves []*Node `json:"slaves"` // 从节点列表
-	Total  int     `json:"total"`  // 总节点数
+	ID     string  `json:"id,omitempty"`   // 拓扑 ID，按节点角色汇总的默认视图为空
+	Name   string  `json:"name,omitempty"` // 拓扑名称
+	Master *Node   `json:"master"`         // 主控节点
+	Slaves []*Node `json:"slaves"`         // 从节点列表
+	Total  int     `json:"total"`          // 总节点数
+}
+
+// Topology 部署拓扑：一台主控机及其从机，如 prod、staging、DR 集群
+type Topology struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	MasterID      string   `json:"masterId"`
+	SlaveIDs      []string `json:"slaveIds"`
+	SlaveSelector string   `json:"slaveSelector,omitempty"` // 从机标签选择器，与 SlaveIDs 合并
+	CreatedAt     string   `json:"createdAt"`
+	UpdatedAt     string   `json:"updatedAt"`
+}
+
+// TopologyCollection 拓扑集合，用于持久化存储
+type TopologyCollection struct {
+	Topologies []*Topology `json:"topologies"`
+	UpdatedAt  time.Time   `json:"updatedAt"`
 }
 
 // NodeCollection 节点集合，用于持久化存储
@@ -134,17 +249,93 @@ const (
 	TaskStatusFailed      TaskStatus = "FAILED"
 )
 
+// IsTerminal 是否为结束状态
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusSuccess || s == TaskStatusFailed
+}
+
+// RunConcurrency 同一任务或目标路径已有运行时的处理策略
+type RunConcurrency string
+
+const (
+	RunConcurrencyReject RunConcurrency = "reject" // 拒绝新的执行请求（默认）
+	RunConcurrencyQueue  RunConcurrency = "queue"  // 排队等待前序运行结束
+	RunConcurrencyAllow  RunConcurrency = "allow"  // 显式允许并发执行
+)
+
+// TransferMode 从机分发方式
+type TransferMode string
+
+const (
+	TransferRelay  TransferMode = "relay"  // 上传至主控机，由主控机 syncd 分发到从机（默认）
+	TransferDirect TransferMode = "direct" // 客户端直传：由本机并行上传到每台从机，不经主控机
+)
+
 // TaskRunRequest 任务执行请求
 type TaskRunRequest struct {
 	TaskID           string            `json:"taskId"`
 	TaskName         string            `json:"taskName,omitempty"`
 	SVNResourceID    string            `json:"svnResourceId"`
 	MasterServerID   string            `json:"masterServerId"`
+	TopologyID       string            `json:"topologyId,omitempty"` // 引用的部署拓扑，设置后主控机与从机取自拓扑
 	SlaveServerIDs   []string          `json:"slaveServerIds"`
+	SlaveSelector    string            `json:"slaveSelector,omitempty"` // 从机标签选择器，如 env=prod,role=web，运行时解析为节点
 	RemotePath       string            `json:"remotePath"`
 	SlaveRemotePath  string            `json:"slaveRemotePath,omitempty"`
 	SlaveRemotePaths map[string]string `json:"slaveRemotePaths,omitempty"`
+	TransferMode     TransferMode      `json:"transferMode,omitempty"` // 从机分发方式，默认经主控机中转
 	Commands         []string          `json:"commands"`
+	Concurrency      RunConcurrency    `json:"concurrency,omitempty"` // 并发策略，默认 reject
+	SVNRevision      string            `json:"svnRevision,omitempty"` // 固定导出的修订号，空表示 HEAD
+}
+
+// RunLockState 运行锁状态
+type RunLockState struct {
+	RunID    string   `json:"runId"`
+	TaskID   string   `json:"taskId"`
+	TaskName string   `json:"taskName,omitempty"`
+	Keys     []string `json:"keys"`  // 锁定的资源键（任务、节点目标路径、导出缓存）
+	State    string   `json:"state"` // running | queued
+	Since    string   `json:"since"`
+}
+
+// LogLevel 日志级别
+type LogLevel string
+
+const (
+	LogLevelInfo    LogLevel = "info"
+	LogLevelWarn    LogLevel = "warn"
+	LogLevelError   LogLevel = "error"
+	LogLevelSuccess LogLevel = "success"
+)
+
+// LogEntry 结构化运行日志
+type LogEntry struct {
+	Time    string            `json:"time"` // 2006-01-02 15:04:05
+	Level   LogLevel          `json:"level"`
+	Phase   RunPhase          `json:"phase,omitempty"`
+	NodeID  string            `json:"nodeId,omitempty"`
+	Message string            `json:"message"`
+	Key     string            `json:"key,omitempty"`    // 消息键，读取时按当前语言重新渲染 Message
+	Args    []string          `json:"args,omitempty"`   // 消息参数
+	Fields  map[string]string `json:"fields,omitempty"` // 附加字段，如修订号、校验和、耗时
+}
+
+// String 返回用于展示的文本形式：[时间] 级别前缀 + 消息
+func (e LogEntry) String() string {
+	prefix := ""
+	switch e.Level {
+	case LogLevelWarn:
+		prefix = "[警告] "
+	case LogLevelError:
+		prefix = "[错误] "
+	case LogLevelSuccess:
+		prefix = "✓ "
+	}
+	if e.Time == "" {
+		return prefix + e.Message
+	}
+	return "[" + e.Time + "] " + prefix + e.Message
 }
 
 // TaskEvent 任务状态事件
@@ -153,7 +344,8 @@ type TaskEvent struct {
 	RunID    string     `json:"runId,omitempty"`
 	Status   TaskStatus `json:"status"`
 	Progress int        `json:"progress"`
-	Log      string     `json:"log"`
+	Log      string     `json:"log"`             // Entry 的展示文本，兼容旧前端
+	Entry    *LogEntry  `json:"entry,omitempty"` // 结构化日志
 }
 
 // ===== 任务编排数据模型 =====
@@ -165,10 +357,13 @@ type TaskDefinition struct {
 	Name             string            `json:"name"`
 	SVNResourceID    string            `json:"svnResourceId"`
 	MasterServerID   string            `json:"masterServerId"`
+	TopologyID       string            `json:"topologyId,omitempty"` // 引用的部署拓扑，设置后主控机与从机取自拓扑
 	SlaveServerIDs   []string          `json:"slaveServerIds"`
+	SlaveSelector    string            `json:"slaveSelector,omitempty"` // 从机标签选择器，如 env=prod,role=web，运行时解析为节点
 	RemotePath       string            `json:"remotePath"`
 	SlaveRemotePath  string            `json:"slaveRemotePath,omitempty"`
 	SlaveRemotePaths map[string]string `json:"slaveRemotePaths,omitempty"`
+	TransferMode     TransferMode      `json:"transferMode,omitempty"` // 从机分发方式，默认经主控机中转
 	Commands         []string          `json:"commands"`
 	Status           TaskStatus        `json:"status"`
 	Progress         int               `json:"progress"`
@@ -184,26 +379,139 @@ type TaskTemplate struct {
 	Name             string            `json:"name"`
 	SVNResourceID    string            `json:"svnResourceId"`
 	MasterServerID   string            `json:"masterServerId"`
+	TopologyID       string            `json:"topologyId,omitempty"` // 引用的部署拓扑，设置后主控机与从机取自拓扑
 	SlaveServerIDs   []string          `json:"slaveServerIds"`
+	SlaveSelector    string            `json:"slaveSelector,omitempty"` // 从机标签选择器，如 env=prod,role=web，运行时解析为节点
 	RemotePath       string            `json:"remotePath"`
 	SlaveRemotePath  string            `json:"slaveRemotePath,omitempty"`
 	SlaveRemotePaths map[string]string `json:"slaveRemotePaths,omitempty"`
+	TransferMode     TransferMode      `json:"transferMode,omitempty"` // 从机分发方式，默认经主控机中转
 	Commands         []string          `json:"commands"`
 	SourceTaskID     string            `json:"sourceTaskId,omitempty"`
 	CreatedAt        string            `json:"createdAt"`
 	UpdatedAt        string            `json:"updatedAt"`
 }
 
+// RunPhase 流水线阶段
+type RunPhase string
+
+const (
+	RunPhaseExport  RunPhase = "export"  // SVN 导出到本地缓存
+	RunPhaseUpload  RunPhase = "upload"  // 上传至主控机
+	RunPhaseSync    RunPhase = "sync"    // 主控机同步从机
+	RunPhaseExecute RunPhase = "execute" // 远程命令执行
+)
+
+// RunCheckpoint 运行断点信息
+// 记录各阶段产物，失败后重试时用于跳过已完成阶段
+type RunCheckpoint struct {
+	ExportPath      string            `json:"exportPath,omitempty"`      // 本地导出缓存路径
+	Revision        string            `json:"revision,omitempty"`        // 导出时的 SVN 修订号
+	Checksum        string            `json:"checksum,omitempty"`        // 导出内容校验和（sha256 清单）
+	MasterID        string            `json:"masterId,omitempty"`        // 已上传的主控节点
+	MasterPath      string            `json:"masterPath,omitempty"`      // 主控机上的资源路径
+	SyncTargets     map[string]string `json:"syncTargets,omitempty"`     // 已同步的从机 ID 到目标路径
+	CompletedPhases []RunPhase        `json:"completedPhases,omitempty"` // 已完成阶段
+}
+
+// SyncedTo 判断同步阶段是否已按相同的从机与目标路径完成
+func (c *RunCheckpoint) SyncedTo(targets map[string]string) bool {
+	if !c.HasCompleted(RunPhaseSync) || c.SyncTargets == nil || len(c.SyncTargets) != len(targets) {
+		return false
+	}
+	for id, dest := range targets {
+		if prev, ok := c.SyncTargets[id]; !ok || prev != dest {
+			return false
+		}
+	}
+	return true
+}
+
+// HasCompleted 判断阶段是否已完成
+func (c *RunCheckpoint) HasCompleted(phase RunPhase) bool {
+	if c == nil {
+		return false
+	}
+	for _, p := range c.CompletedPhases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// RunStepStatus 步骤结果
+type RunStepStatus string
+
+const (
+	RunStepSuccess RunStepStatus = "success"
+	RunStepFailed  RunStepStatus = "failed"
+	RunStepSkipped RunStepStatus = "skipped" // 重试时复用已完成结果
+)
+
+// RunStep 运行步骤记录，构成按节点的执行时间线
+type RunStep struct {
+	Phase      RunPhase      `json:"phase"`
+	NodeID     string        `json:"nodeId,omitempty"` // 空表示本地执行
+	Name       string        `json:"name"`
+	StartedAt  string        `json:"startedAt"`
+	FinishedAt string        `json:"finishedAt"`
+	DurationMs int64         `json:"durationMs"`
+	Status     RunStepStatus `json:"status"`
+	Output     string        `json:"output,omitempty"` // 命令输出尾部片段，完整输出存于运行日志
+	Error      string        `json:"error,omitempty"`
+}
+
+// RunSnapshot 运行创建时解析出的配置快照，之后修改任务或节点不影响快照
+type RunSnapshot struct {
+	Request      TaskRunRequest  `json:"request"`
+	ResourceURL  string          `json:"resourceUrl"`
+	ResourceType SVNResourceType `json:"resourceType,omitempty"`
+	Revision     string          `json:"revision,omitempty"` // 实际导出的修订号，导出完成后补全
+	Master       *Node           `json:"master,omitempty"`
+	Slaves       []*Node         `json:"slaves,omitempty"`
+	CreatedAt    string          `json:"createdAt"`
+}
+
 // TaskRun 任务执行历史
 type TaskRun struct {
-	ID         string     `json:"id"`
-	TaskID     string     `json:"taskId"`
-	TaskName   string     `json:"taskName"`
-	Status     TaskStatus `json:"status"`
-	Progress   int        `json:"progress"`
-	StartedAt  string     `json:"startedAt"`
-	FinishedAt string     `json:"finishedAt,omitempty"`
-	Logs       []string   `json:"logs"`
+	ID          string         `json:"id"`
+	TaskID      string         `json:"taskId"`
+	TaskName    string         `json:"taskName"`
+	Status      TaskStatus     `json:"status"`
+	Progress    int            `json:"progress"`
+	StartedAt   string         `json:"startedAt"`
+	FinishedAt  string         `json:"finishedAt,omitempty"`
+	Logs        []string       `json:"logs,omitempty"`        // 仅在读取单次运行日志时填充，摘要中不持久化
+	LogCount    int            `json:"logCount"`              // 日志行数
+	RetryOf     string         `json:"retryOf,omitempty"`     // 重试来源运行 ID
+	FailedPhase RunPhase       `json:"failedPhase,omitempty"` // 失败所在阶段
+	Error       string         `json:"error,omitempty"`       // 失败原因
+	ErrorKey    string         `json:"errorKey,omitempty"`    // 失败原因的消息 key，用于统计归类
+	Checkpoint  *RunCheckpoint `json:"checkpoint,omitempty"`  // 断点信息
+	SVNRevision string         `json:"svnRevision,omitempty"` // 部署的 SVN 修订号
+	NodeIDs     []string       `json:"nodeIds,omitempty"`     // 目标节点（主控在前）
+	Steps       []RunStep      `json:"steps,omitempty"`       // 执行时间线
+	Snapshot    *RunSnapshot   `json:"snapshot,omitempty"`    // 配置快照
+}
+
+// RunQuery 运行历史检索条件，空值表示不限制
+type RunQuery struct {
+	TaskID   string       `json:"taskId,omitempty"`
+	Statuses []TaskStatus `json:"statuses,omitempty"`
+	From     string       `json:"from,omitempty"`     // 开始时间下限，格式 2006-01-02 或 2006-01-02 15:04:05
+	To       string       `json:"to,omitempty"`       // 开始时间上限，仅日期时包含当天
+	NodeID   string       `json:"nodeId,omitempty"`   // 节点 ID
+	Revision string       `json:"revision,omitempty"` // SVN 修订号，可带 r 前缀
+	Text     string       `json:"text,omitempty"`     // 日志全文关键字，不区分大小写
+	Offset   int          `json:"offset"`
+	Limit    int          `json:"limit"` // 默认 50
+}
+
+// RunQueryResult 运行历史检索结果
+type RunQueryResult struct {
+	Runs  []*TaskRun `json:"runs"`
+	Total int        `json:"total"` // 符合条件的总数（分页前）
 }
 
 // TaskStore 任务持久化存储集合
@@ -213,3 +521,94 @@ type TaskStore struct {
 	Runs      []*TaskRun        `json:"runs"`
 	UpdatedAt time.Time         `json:"updatedAt"`
 }
+
+// ===== 部署统计 =====
+
+// DailyRunStats 单日运行统计
+type DailyRunStats struct {
+	Date    string `json:"date"` // 2006-01-02
+	Total   int    `json:"total"`
+	Success int    `json:"success"`
+	Failed  int    `json:"failed"`
+}
+
+// TaskRunStats 单任务运行统计
+type TaskRunStats struct {
+	TaskID      string  `json:"taskId"`
+	TaskName    string  `json:"taskName"`
+	Total       int     `json:"total"`
+	Success     int     `json:"success"`
+	Failed      int     `json:"failed"`
+	SuccessRate float64 `json:"successRate"` // 0-1，按已结束运行计算
+}
+
+// PhaseDurationStats 阶段耗时统计，跳过的步骤不计入
+type PhaseDurationStats struct {
+	Phase  RunPhase `json:"phase"`
+	Count  int      `json:"count"`
+	MeanMs int64    `json:"meanMs"`
+	P95Ms  int64    `json:"p95Ms"`
+}
+
+// FailureCount 失败次数统计项
+type FailureCount struct {
+	Key   string `json:"key"`             // 节点 ID、阶段或“阶段/错误消息 key”
+	Label string `json:"label,omitempty"` // 展示名称
+	Count int    `json:"count"`
+}
+
+// DeploymentStats 部署统计
+type DeploymentStats struct {
+	From           string               `json:"from,omitempty"` // 统计起始日期，空表示全部
+	Total          int                  `json:"total"`
+	Success        int                  `json:"success"`
+	Failed         int                  `json:"failed"`
+	RunsPerDay     []DailyRunStats      `json:"runsPerDay"`
+	Tasks          []TaskRunStats       `json:"tasks"`
+	PhaseDurations []PhaseDurationStats `json:"phaseDurations"`
+	FailingNodes   []FailureCount       `json:"failingNodes"`
+	FailingPhases  []FailureCount       `json:"failingPhases"`
+	TopErrors      []FailureCount       `json:"topErrors"`
+}
+
+// ===== 应用设置 =====
+
+// RunRetention 运行历史保留策略
+// 数值为 0 表示不限制
+type RunRetention struct {
+	MaxRunsPerTask int `json:"maxRunsPerTask"` // 每个任务最多保留的运行数
+	MaxAgeDays     int `json:"maxAgeDays"`     // 运行记录最长保留天数
+}
+
+// Telemetry 节点资源遥测设置
+type Telemetry struct {
+	IntervalSeconds int      `json:"intervalSeconds"` // 主控机定期采集间隔，0 表示关闭
+	DiskPaths       []string `json:"diskPaths"`       // 统计可用空间的远程路径
+}
+
+// SSHKeepAlive SSH 连接保活与空闲检测设置
+type SSHKeepAlive struct {
+	IntervalSeconds    int `json:"intervalSeconds"`    // 保活请求间隔，0 表示不发送
+	MaxMissed          int `json:"maxMissed"`          // 连续未响应次数达到该值时断开连接
+	IdleTimeoutSeconds int `json:"idleTimeoutSeconds"` // 远程命令无任何输出的最长时间，0 表示不限制；syncd 同步不受限制
+}
+
+// StorageBackend 数据存储后端
+type StorageBackend string
+
+const (
+	StorageJSON   StorageBackend = "json"   // JSON 文件（默认）
+	StorageSQLite StorageBackend = "sqlite" // SQLite 数据库
+)
+
+// AppSettings 应用设置，存储于数据目录 settings.json
+type AppSettings struct {
+	RunRetention   RunRetention   `json:"runRetention"`
+	StorageBackend StorageBackend `json:"storageBackend"` // 修改后重启生效
+	Language       string         `json:"language"`       // 日志与错误信息语言：zh-CN | en-US
+	SSHKeepAlive   SSHKeepAlive   `json:"sshKeepAlive"`
+	// 节点心跳间隔（秒），0 表示关闭心跳
+	HeartbeatSeconds int       `json:"heartbeatSeconds"`
+	Telemetry        Telemetry `json:"telemetry"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
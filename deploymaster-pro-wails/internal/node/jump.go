@@ -0,0 +1,72 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidJumpHost 跳板机不存在、指向自身或不支持 SSH
+	ErrInvalidJumpHost = errors.New("invalid jump host")
+	// ErrJumpCycle 跳板关系形成环
+	ErrJumpCycle = errors.New("jump host chain forms a cycle")
+)
+
+// JumpChain 返回连接节点所需经过的跳板机，按连接顺序排列：
+// 第一个直接连接，其后各跳经上一跳转发，最后一个为节点的 JumpHostID
+func (s *Service) JumpChain(nodeID string) ([]*internal.Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n, err := s.findLocked(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var chain []*internal.Node
+	seen := map[string]bool{n.ID: true}
+	for id := n.JumpHostID; id != ""; {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: %s", ErrJumpCycle, id)
+		}
+		seen[id] = true
+		hop, err := s.findLocked(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidJumpHost, id)
+		}
+		chain = append([]*internal.Node{hop}, chain...)
+		id = hop.JumpHostID
+	}
+	return chain, nil
+}
+
+// validateJumpHostLocked 校验节点的跳板机存在、使用 SSH 协议且不会形成环
+func (s *Service) validateJumpHostLocked(node *internal.Node) error {
+	if node.JumpHostID == "" {
+		return nil
+	}
+	if node.JumpHostID == node.ID {
+		return fmt.Errorf("%w: node cannot jump through itself", ErrInvalidJumpHost)
+	}
+	if node.Protocol.IsFTP() {
+		return fmt.Errorf("%w: FTP nodes cannot be reached through a jump host", ErrInvalidJumpHost)
+	}
+
+	seen := map[string]bool{node.ID: true}
+	for id := node.JumpHostID; id != ""; {
+		if seen[id] {
+			return fmt.Errorf("%w: %s", ErrJumpCycle, id)
+		}
+		seen[id] = true
+		hop, err := s.findLocked(id)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidJumpHost, id)
+		}
+		if hop.Protocol.IsFTP() {
+			return fmt.Errorf("%w: %s uses FTP", ErrInvalidJumpHost, hop.Name)
+		}
+		id = hop.JumpHostID
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"testing"
+)
+
+func TestJumpChain(t *testing.T) {
+	storage, _ := NewJSONStorage(t.TempDir())
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	// 桌面 → 堡垒机 bastion → 内网跳板 inner → app
+	for _, n := range []*internal.Node{
+		{ID: "bastion", Name: "bastion"},
+		{ID: "inner", Name: "inner", JumpHostID: "bastion"},
+		{ID: "app", Name: "app", JumpHostID: "inner"},
+		{ID: "ftp", Name: "ftp", Protocol: internal.ProtocolFTP},
+	} {
+		if err := service.AddNode(n); err != nil {
+			t.Fatalf("Failed to add node %s: %v", n.ID, err)
+		}
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		chain, err := service.JumpChain("app")
+		if err != nil {
+			t.Fatalf("Failed to resolve chain: %v", err)
+		}
+		if len(chain) != 2 || chain[0].ID != "bastion" || chain[1].ID != "inner" {
+			t.Errorf("Unexpected chain: %+v", chain)
+		}
+		if chain, _ := service.JumpChain("bastion"); len(chain) != 0 {
+			t.Errorf("Expected direct connection for bastion, got %+v", chain)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		if err := service.AddNode(&internal.Node{ID: "x", JumpHostID: "missing"}); !errors.Is(err, ErrInvalidJumpHost) {
+			t.Errorf("Expected ErrInvalidJumpHost, got %v", err)
+		}
+		if err := service.AddNode(&internal.Node{ID: "y", JumpHostID: "ftp"}); !errors.Is(err, ErrInvalidJumpHost) {
+			t.Errorf("Expected ErrInvalidJumpHost for FTP jump host, got %v", err)
+		}
+		if err := service.UpdateNode(&internal.Node{ID: "ftp", Name: "ftp", Protocol: internal.ProtocolFTP, JumpHostID: "bastion"}); !errors.Is(err, ErrInvalidJumpHost) {
+			t.Errorf("Expected ErrInvalidJumpHost for FTP node, got %v", err)
+		}
+		if err := service.UpdateNode(&internal.Node{ID: "bastion", JumpHostID: "app"}); !errors.Is(err, ErrJumpCycle) {
+			t.Errorf("Expected ErrJumpCycle, got %v", err)
+		}
+	})
+
+	t.Run("DeleteRelinks", func(t *testing.T) {
+		if err := service.DeleteNode("inner"); err != nil {
+			t.Fatalf("Failed to delete node: %v", err)
+		}
+		chain, err := service.JumpChain("app")
+		if err != nil || len(chain) != 1 || chain[0].ID != "bastion" {
+			t.Errorf("Expected app to jump through bastion, got %+v (%v)", chain, err)
+		}
+	})
+}
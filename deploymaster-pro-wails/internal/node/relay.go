@@ -0,0 +1,121 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidParent 中转父节点不存在或指向自身
+	ErrInvalidParent = errors.New("invalid relay parent")
+	// ErrRelayCycle 中转关系形成环
+	ErrRelayCycle = errors.New("relay parent chain forms a cycle")
+)
+
+// RelayNode 分发树中的一个节点
+// 主控机只向树的第一层传输一份文件，各中转节点再由其 syncd 向下一层分发，
+// 跨地域链路上每个区域只传输一份
+type RelayNode struct {
+	Node     *internal.Node
+	Target   bool         // 是否为部署目标；为 false 时仅作中转，文件暂存后清理
+	Children []*RelayNode // 经由本节点分发的下级节点
+}
+
+// Count 返回子树中的节点总数（含自身）
+func (r *RelayNode) Count() int {
+	total := 1
+	for _, c := range r.Children {
+		total += c.Count()
+	}
+	return total
+}
+
+// BuildRelayTree 按节点的 ParentID 为目标节点构建分发树
+// 目标节点沿父链向上追溯，直到主控机或无父节点为止；链上不在目标中的节点作为中转节点加入树。
+// 返回主控机直接分发的第一层节点，顺序与 targetIDs 中首次出现的顺序一致
+func BuildRelayTree(nodes []*internal.Node, masterID string, targetIDs []string) ([]*RelayNode, error) {
+	byID := make(map[string]*internal.Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	targets := make(map[string]bool, len(targetIDs))
+	for _, id := range targetIDs {
+		targets[id] = true
+	}
+
+	built := make(map[string]*RelayNode)
+	roots := make([]*RelayNode, 0, len(targetIDs))
+
+	// attach 将节点及其未加入树的祖先链挂入树中
+	var attach func(id string, visiting map[string]bool) (*RelayNode, error)
+	attach = func(id string, visiting map[string]bool) (*RelayNode, error) {
+		if r, ok := built[id]; ok {
+			return r, nil
+		}
+		if visiting[id] {
+			return nil, fmt.Errorf("%w: %s", ErrRelayCycle, id)
+		}
+		visiting[id] = true
+
+		n, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, id)
+		}
+		r := &RelayNode{Node: n, Target: targets[id]}
+
+		if n.ParentID == "" || n.ParentID == masterID {
+			roots = append(roots, r)
+		} else {
+			parent, err := attach(n.ParentID, visiting)
+			if err != nil {
+				return nil, err
+			}
+			parent.Children = append(parent.Children, r)
+		}
+		built[id] = r
+		return r, nil
+	}
+
+	for _, id := range targetIDs {
+		if id == masterID {
+			continue
+		}
+		if _, err := attach(id, make(map[string]bool)); err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
+
+// RelayTree 基于当前节点列表构建分发树
+func (s *Service) RelayTree(masterID string, targetIDs []string) ([]*RelayNode, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return BuildRelayTree(s.nodes, masterID, targetIDs)
+}
+
+// validateParentLocked 校验节点的中转父节点存在且不会形成环
+func (s *Service) validateParentLocked(node *internal.Node) error {
+	if node.ParentID == "" {
+		return nil
+	}
+	if node.ParentID == node.ID {
+		return fmt.Errorf("%w: node cannot relay through itself", ErrInvalidParent)
+	}
+
+	seen := map[string]bool{node.ID: true}
+	for id := node.ParentID; id != ""; {
+		if seen[id] {
+			return fmt.Errorf("%w: %s", ErrRelayCycle, id)
+		}
+		seen[id] = true
+		parent, err := s.findLocked(id)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidParent, id)
+		}
+		id = parent.ParentID
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"testing"
+)
+
+func TestRelayTree(t *testing.T) {
+	storage, _ := NewJSONStorage(t.TempDir())
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	// 桌面 → 主控 m → 区域中转 hk → 本地中转 hk-a → s1/s2；s3 由主控直连
+	for _, n := range []*internal.Node{
+		{ID: "m", Name: "master", IsMaster: true},
+		{ID: "hk", Name: "hk-relay", ParentID: "m"},
+		{ID: "hk-a", Name: "hk-a", ParentID: "hk"},
+		{ID: "s1", Name: "s1", ParentID: "hk-a"},
+		{ID: "s2", Name: "s2", ParentID: "hk-a"},
+		{ID: "s3", Name: "s3"},
+	} {
+		if err := service.AddNode(n); err != nil {
+			t.Fatalf("Failed to add node %s: %v", n.ID, err)
+		}
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		roots, err := service.RelayTree("m", []string{"s1", "s3", "s2", "hk-a"})
+		if err != nil {
+			t.Fatalf("Failed to build tree: %v", err)
+		}
+		if len(roots) != 2 || roots[0].Node.ID != "hk" || roots[1].Node.ID != "s3" {
+			t.Fatalf("Unexpected roots: %+v", roots)
+		}
+		hk := roots[0]
+		if hk.Target || len(hk.Children) != 1 || hk.Count() != 4 {
+			t.Errorf("Expected hk as relay-only with one child, got %+v", hk)
+		}
+		local := hk.Children[0]
+		if !local.Target || len(local.Children) != 2 || local.Children[0].Node.ID != "s1" || local.Children[1].Node.ID != "s2" {
+			t.Errorf("Unexpected local relay: %+v", local)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		if err := service.AddNode(&internal.Node{ID: "x", ParentID: "missing"}); !errors.Is(err, ErrInvalidParent) {
+			t.Errorf("Expected ErrInvalidParent, got %v", err)
+		}
+		if err := service.UpdateNode(&internal.Node{ID: "hk", ParentID: "hk"}); !errors.Is(err, ErrInvalidParent) {
+			t.Errorf("Expected ErrInvalidParent for self parent, got %v", err)
+		}
+		if err := service.UpdateNode(&internal.Node{ID: "hk", ParentID: "s1"}); !errors.Is(err, ErrRelayCycle) {
+			t.Errorf("Expected ErrRelayCycle, got %v", err)
+		}
+	})
+
+	t.Run("DeleteReparents", func(t *testing.T) {
+		if err := service.DeleteNode("hk-a"); err != nil {
+			t.Fatalf("Failed to delete relay: %v", err)
+		}
+		s1, _ := service.GetNode("s1")
+		if s1.ParentID != "hk" {
+			t.Errorf("Expected s1 to move under hk, got %q", s1.ParentID)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidTag 标签键或值不合法
+	ErrInvalidTag = errors.New("invalid node tag")
+	// ErrNoMatchingNodes 选择器未匹配任何节点
+	ErrNoMatchingNodes = errors.New("selector matched no nodes")
+)
+
+// tagKeyPattern 标签键：字母、数字与 . _ - /，如 env、role、app.kubernetes.io/name
+var tagKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// ValidateTag 校验标签键与值；值可以为空，表示仅作为分组标记（如 canary）
+func ValidateTag(key, value string) error {
+	if !tagKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: key %q", ErrInvalidTag, key)
+	}
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, ",|=!") {
+		return fmt.Errorf("%w: value %q", ErrInvalidTag, value)
+	}
+	return nil
+}
+
+// selectorOp 选择器条件类型
+type selectorOp int
+
+const (
+	opEquals    selectorOp = iota // key=a 或 key=a|b
+	opNotEquals                   // key!=a 或 key!=a|b
+	opExists                      // key
+	opNotExists                   // !key
+)
+
+type requirement struct {
+	key    string
+	op     selectorOp
+	values []string
+}
+
+// Selector 节点标签选择器，多个条件之间为“且”关系
+// 语法示例："env=prod,role=web|api,!canary"
+type Selector struct {
+	requirements []requirement
+}
+
+// ParseSelector 解析选择器表达式；空表达式返回空选择器（不匹配任何节点）
+func ParseSelector(expr string) (*Selector, error) {
+	s := &Selector{}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var req requirement
+		var rawValues string
+		switch {
+		case strings.Contains(part, "!="):
+			idx := strings.Index(part, "!=")
+			req = requirement{key: strings.TrimSpace(part[:idx]), op: opNotEquals}
+			rawValues = part[idx+2:]
+		case strings.Contains(part, "="):
+			idx := strings.Index(part, "=")
+			req = requirement{key: strings.TrimSpace(part[:idx]), op: opEquals}
+			rawValues = part[idx+1:]
+		case strings.HasPrefix(part, "!"):
+			req = requirement{key: strings.TrimSpace(part[1:]), op: opNotExists}
+		default:
+			req = requirement{key: part, op: opExists}
+		}
+
+		if !tagKeyPattern.MatchString(req.key) {
+			return nil, fmt.Errorf("invalid selector %q: bad key %q", part, req.key)
+		}
+		if req.op == opEquals || req.op == opNotEquals {
+			for _, v := range strings.Split(rawValues, "|") {
+				v = strings.TrimSpace(v)
+				if strings.ContainsAny(v, "=!") {
+					return nil, fmt.Errorf("invalid selector %q: bad value %q", part, v)
+				}
+				req.values = append(req.values, v)
+			}
+		}
+		s.requirements = append(s.requirements, req)
+	}
+	return s, nil
+}
+
+// Empty 是否未包含任何条件
+func (s *Selector) Empty() bool {
+	return s == nil || len(s.requirements) == 0
+}
+
+// Matches 判断节点是否满足全部条件；空选择器不匹配任何节点
+func (s *Selector) Matches(n *internal.Node) bool {
+	if s.Empty() {
+		return false
+	}
+	for _, req := range s.requirements {
+		value, ok := n.Tags[req.key]
+		switch req.op {
+		case opExists:
+			if !ok {
+				return false
+			}
+		case opNotExists:
+			if ok {
+				return false
+			}
+		case opEquals:
+			if !ok || !containsValue(req.values, value) {
+				return false
+			}
+		case opNotEquals:
+			if ok && containsValue(req.values, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
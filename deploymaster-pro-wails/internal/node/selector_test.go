@@ -0,0 +1,105 @@
+package node
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	web := &internal.Node{ID: "web", Tags: map[string]string{"env": "prod", "role": "web", "canary": ""}}
+	api := &internal.Node{ID: "api", Tags: map[string]string{"env": "prod", "role": "api"}}
+	dev := &internal.Node{ID: "dev", Tags: map[string]string{"env": "dev", "role": "web"}}
+	bare := &internal.Node{ID: "bare"}
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"env=prod", []string{"web", "api"}},
+		{"env=prod,role=web", []string{"web"}},
+		{"role=web|api", []string{"web", "api", "dev"}},
+		{"env!=prod", []string{"dev", "bare"}},
+		{"canary", []string{"web"}},
+		{"env=prod, !canary", []string{"api"}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			selector, err := ParseSelector(c.expr)
+			if err != nil {
+				t.Fatalf("Failed to parse selector: %v", err)
+			}
+			var got []string
+			for _, n := range []*internal.Node{web, api, dev, bare} {
+				if selector.Matches(n) {
+					got = append(got, n.ID)
+				}
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("Expected %v, got %v", c.want, got)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("Expected %v, got %v", c.want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, expr := range []string{"=prod", "env=a=b", "bad key"} {
+			if _, err := ParseSelector(expr); err == nil {
+				t.Errorf("Expected error for %q", expr)
+			}
+		}
+	})
+}
+
+func TestNodeTags(t *testing.T) {
+	storage, _ := NewJSONStorage(t.TempDir())
+	service, _ := NewService(storage)
+	_ = service.AddNode(&internal.Node{ID: "n1", Tags: map[string]string{"env": "prod"}})
+	_ = service.AddNode(&internal.Node{ID: "n2"})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := service.AddNode(&internal.Node{ID: "n3", Tags: map[string]string{"env": "a,b"}})
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("Expected ErrInvalidTag, got %v", err)
+		}
+		if err := service.AddNodeTag("n2", "", "x"); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("Expected ErrInvalidTag for empty key, got %v", err)
+		}
+	})
+
+	t.Run("CRUD", func(t *testing.T) {
+		if err := service.AddNodeTag("n2", "env", "dev"); err != nil {
+			t.Fatalf("Failed to add tag: %v", err)
+		}
+		if err := service.SetNodeTags("n1", map[string]string{"env": "prod", "role": "web"}); err != nil {
+			t.Fatalf("Failed to set tags: %v", err)
+		}
+		if err := service.RemoveNodeTag("n1", "role"); err != nil {
+			t.Fatalf("Failed to remove tag: %v", err)
+		}
+		if err := service.AddNodeTag("missing", "env", "dev"); !errors.Is(err, ErrNodeNotFound) {
+			t.Errorf("Expected ErrNodeNotFound, got %v", err)
+		}
+
+		tags := service.ListTags()
+		if len(tags) != 1 || len(tags["env"]) != 2 || tags["env"][0] != "dev" {
+			t.Errorf("Unexpected tag summary: %v", tags)
+		}
+	})
+
+	t.Run("SelectAfterReload", func(t *testing.T) {
+		reloaded, _ := NewService(storage)
+		nodes, err := reloaded.SelectNodes("env=dev")
+		if err != nil {
+			t.Fatalf("Failed to select nodes: %v", err)
+		}
+		if len(nodes) != 1 || nodes[0].ID != "n2" {
+			t.Errorf("Unexpected selection: %+v", nodes)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"deploymaster-pro-wails/internal"
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -65,6 +66,10 @@ func (s *Service) AddNode(node *internal.Node) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := validateTags(node.Tags); err != nil {
+		return err
+	}
+
 	// 后端兜底生成 ID，避免空 ID 导致删除/更新失败
 	if node.ID == "" {
 		node.ID = uuid.NewString()
@@ -76,6 +81,12 @@ func (s *Service) AddNode(node *internal.Node) error {
 			return ErrNodeExists
 		}
 	}
+	if err := s.validateParentLocked(node); err != nil {
+		return err
+	}
+	if err := s.validateJumpHostLocked(node); err != nil {
+		return err
+	}
 
 	s.nodes = append(s.nodes, node)
 	return s.saveNodes()
@@ -83,11 +94,21 @@ func (s *Service) AddNode(node *internal.Node) error {
 
 // UpdateNode 更新节点信息
 func (s *Service) UpdateNode(node *internal.Node) error {
+	if err := validateTags(node.Tags); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	for i, n := range s.nodes {
 		if n.ID == node.ID {
+			if err := s.validateParentLocked(node); err != nil {
+				return err
+			}
+			if err := s.validateJumpHostLocked(node); err != nil {
+				return err
+			}
 			s.nodes[i] = node
 			return s.saveNodes()
 		}
@@ -103,6 +124,16 @@ func (s *Service) DeleteNode(nodeID string) error {
 
 	for i, n := range s.nodes {
 		if n.ID == nodeID {
+			// 经由该节点中转的下级节点改挂到其父节点，保持原有分发路径
+			for _, child := range s.nodes {
+				if child.ParentID == nodeID {
+					child.ParentID = n.ParentID
+				}
+				// 经由该节点跳转的节点改用其上一跳
+				if child.JumpHostID == nodeID {
+					child.JumpHostID = n.JumpHostID
+				}
+			}
 			// 删除节点（保持顺序）
 			s.nodes = append(s.nodes[:i], s.nodes[i+1:]...)
 			return s.saveNodes()
@@ -137,7 +168,7 @@ func (s *Service) ListNodes() []*internal.Node {
 	return result
 }
 
-// GetMasterNode 获取主控节点
+// GetMasterNode 获取主控节点，存在多个时返回第一个
 func (s *Service) GetMasterNode() (*internal.Node, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -151,6 +182,20 @@ func (s *Service) GetMasterNode() (*internal.Node, error) {
 	return nil, errors.New("no master node configured")
 }
 
+// GetMasterNodes 获取所有标记为主控的节点（多套拓扑时每套各有一台主控机）
+func (s *Service) GetMasterNodes() []*internal.Node {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	masters := make([]*internal.Node, 0)
+	for _, n := range s.nodes {
+		if n.IsMaster {
+			masters = append(masters, n)
+		}
+	}
+	return masters
+}
+
 // GetSlaveNodes 获取所有从节点
 func (s *Service) GetSlaveNodes() []*internal.Node {
 	s.mu.RLock()
@@ -165,3 +210,135 @@ func (s *Service) GetSlaveNodes() []*internal.Node {
 
 	return slaves
 }
+
+// SetNodeTags 整体替换节点标签
+func (s *Service) SetNodeTags(nodeID string, tags map[string]string) error {
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.findLocked(nodeID)
+	if err != nil {
+		return err
+	}
+	n.Tags = copyTags(tags)
+	return s.saveNodes()
+}
+
+// AddNodeTag 添加或修改节点标签
+func (s *Service) AddNodeTag(nodeID, key, value string) error {
+	if err := ValidateTag(key, value); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.findLocked(nodeID)
+	if err != nil {
+		return err
+	}
+	tags := copyTags(n.Tags)
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	tags[key] = value
+	n.Tags = tags
+	return s.saveNodes()
+}
+
+// RemoveNodeTag 删除节点标签，标签不存在时不报错
+func (s *Service) RemoveNodeTag(nodeID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.findLocked(nodeID)
+	if err != nil {
+		return err
+	}
+	if _, ok := n.Tags[key]; !ok {
+		return nil
+	}
+	tags := copyTags(n.Tags)
+	delete(tags, key)
+	n.Tags = tags
+	return s.saveNodes()
+}
+
+// ListTags 汇总所有节点的标签键及其取值（已排序），用于选择器输入提示
+func (s *Service) ListTags() map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]map[string]bool)
+	for _, n := range s.nodes {
+		for k, v := range n.Tags {
+			if seen[k] == nil {
+				seen[k] = make(map[string]bool)
+			}
+			seen[k][v] = true
+		}
+	}
+
+	result := make(map[string][]string, len(seen))
+	for k, values := range seen {
+		list := make([]string, 0, len(values))
+		for v := range values {
+			list = append(list, v)
+		}
+		sort.Strings(list)
+		result[k] = list
+	}
+	return result
+}
+
+// SelectNodes 返回满足选择器的节点，保持节点列表顺序
+func (s *Service) SelectNodes(expr string) ([]*internal.Node, error) {
+	selector, err := ParseSelector(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*internal.Node, 0)
+	for _, n := range s.nodes {
+		if selector.Matches(n) {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) findLocked(nodeID string) (*internal.Node, error) {
+	for _, n := range s.nodes {
+		if n.ID == nodeID {
+			return n, nil
+		}
+	}
+	return nil, ErrNodeNotFound
+}
+
+func validateTags(tags map[string]string) error {
+	for k, v := range tags {
+		if err := ValidateTag(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,361 @@
+package report
+
+import (
+	"bytes"
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/i18n"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// Format 报告格式
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// ErrUnsupportedFormat 不支持的报告格式
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+// ParseFormat 解析报告格式，支持 html/htm、markdown/md、json
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "html", "htm":
+		return FormatHTML, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Ext 返回格式对应的文件扩展名
+func (f Format) Ext() string {
+	switch f {
+	case FormatMarkdown:
+		return ".md"
+	case FormatJSON:
+		return ".json"
+	}
+	return ".html"
+}
+
+// Report 单次运行的执行报告
+type Report struct {
+	GeneratedAt string                   `json:"generatedAt"`
+	Run         *internal.TaskRun        `json:"run"`
+	Task        *internal.TaskDefinition `json:"task,omitempty"`     // 当前任务定义，运行无快照时用作配置
+	Resource    *internal.SVNResource    `json:"resource,omitempty"` // SVN 资源
+	Nodes       []*internal.Node         `json:"nodes"`              // 目标节点，主控在前
+	Logs        []string                 `json:"logs"`
+	Lang        i18n.Lang                `json:"lang"` // 标签语言
+}
+
+// New 创建报告，lang 决定报告标签的语言
+func New(run *internal.TaskRun, def *internal.TaskDefinition, resource *internal.SVNResource, nodes []*internal.Node, logs []string, lang i18n.Lang) *Report {
+	if nodes == nil {
+		nodes = []*internal.Node{}
+	}
+	if logs == nil {
+		logs = []string{}
+	}
+	return &Report{
+		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
+		Run:         run,
+		Task:        def,
+		Resource:    resource,
+		Nodes:       nodes,
+		Logs:        logs,
+		Lang:        lang,
+	}
+}
+
+// Render 按格式渲染报告
+func Render(r *Report, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(r, "", "  ")
+	case FormatMarkdown:
+		return renderMarkdown(r), nil
+	case FormatHTML:
+		var buf bytes.Buffer
+		if err := htmlTemplate.Execute(&buf, r); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// T 按报告语言渲染标签
+func (r *Report) T(key string, args ...any) string {
+	return i18n.T(r.Lang, key, args...)
+}
+
+// Config 返回运行时的任务配置：优先取运行快照，旧运行取当前任务定义
+func (r *Report) Config() *internal.TaskRunRequest {
+	if r.Run.Snapshot != nil {
+		return &r.Run.Snapshot.Request
+	}
+	if r.Task == nil {
+		return nil
+	}
+	return &internal.TaskRunRequest{
+		TaskID:           r.Task.ID,
+		TaskName:         r.Task.Name,
+		SVNResourceID:    r.Task.SVNResourceID,
+		MasterServerID:   r.Task.MasterServerID,
+		TopologyID:       r.Task.TopologyID,
+		SlaveServerIDs:   r.Task.SlaveServerIDs,
+		SlaveSelector:    r.Task.SlaveSelector,
+		RemotePath:       r.Task.RemotePath,
+		SlaveRemotePath:  r.Task.SlaveRemotePath,
+		SlaveRemotePaths: r.Task.SlaveRemotePaths,
+		TransferMode:     r.Task.TransferMode,
+		Commands:         r.Task.Commands,
+	}
+}
+
+// ResourceURL 返回 SVN 地址
+func (r *Report) ResourceURL() string {
+	if r.Run.Snapshot != nil && r.Run.Snapshot.ResourceURL != "" {
+		return r.Run.Snapshot.ResourceURL
+	}
+	if r.Resource != nil {
+		return r.Resource.URL
+	}
+	return ""
+}
+
+// NodeName 返回节点展示名称：当前节点、快照节点依次查找，均无时返回 ID
+func (r *Report) NodeName(nodeID string) string {
+	if nodeID == "" {
+		return r.T("report.local")
+	}
+	nodes := r.Nodes
+	if s := r.Run.Snapshot; s != nil {
+		if s.Master != nil {
+			nodes = append(append([]*internal.Node{}, nodes...), s.Master)
+		}
+		nodes = append(nodes, s.Slaves...)
+	}
+	for _, n := range nodes {
+		if n.ID == nodeID {
+			if n.Name != "" {
+				return fmt.Sprintf("%s (%s)", n.Name, n.IP)
+			}
+			return n.IP
+		}
+	}
+	return nodeID
+}
+
+// Revision 返回部署的修订号
+func (r *Report) Revision() string {
+	if r.Run.SVNRevision != "" {
+		return r.Run.SVNRevision
+	}
+	if r.Run.Checkpoint != nil {
+		return r.Run.Checkpoint.Revision
+	}
+	return ""
+}
+
+// Checksum 返回导出内容校验和
+func (r *Report) Checksum() string {
+	if r.Run.Checkpoint != nil {
+		return r.Run.Checkpoint.Checksum
+	}
+	return ""
+}
+
+// Duration 返回运行总耗时
+func (r *Report) Duration() string {
+	start, err := time.ParseInLocation("2006-01-02 15:04:05", r.Run.StartedAt, time.Local)
+	if err != nil {
+		return ""
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04:05", r.Run.FinishedAt, time.Local)
+	if err != nil {
+		return ""
+	}
+	return formatDuration(end.Sub(start).Milliseconds())
+}
+
+func formatDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+func renderMarkdown(r *Report) []byte {
+	var b strings.Builder
+	run := r.Run
+
+	fmt.Fprintf(&b, "# %s\n\n", r.T("report.title", mdEscape(run.TaskName)))
+	mdHeader(&b, r.T("report.item"), r.T("report.value"))
+	mdRow(&b, r.T("report.runID"), run.ID)
+	mdRow(&b, r.T("report.status"), string(run.Status))
+	if run.FailedPhase != "" {
+		mdRow(&b, r.T("report.failedPhase"), string(run.FailedPhase))
+	}
+	mdRow(&b, r.T("report.startedAt"), run.StartedAt)
+	mdRow(&b, r.T("report.finishedAt"), run.FinishedAt)
+	mdRow(&b, r.T("report.duration"), r.Duration())
+	if run.RetryOf != "" {
+		mdRow(&b, r.T("report.retryOf"), run.RetryOf)
+	}
+	mdRow(&b, r.T("report.svnURL"), r.ResourceURL())
+	mdRow(&b, r.T("report.revision"), r.Revision())
+	mdRow(&b, r.T("report.checksum"), r.Checksum())
+	mdRow(&b, r.T("report.generatedAt"), r.GeneratedAt)
+
+	if cfg := r.Config(); cfg != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", r.T("report.config"))
+		mdHeader(&b, r.T("report.item"), r.T("report.value"))
+		mdRow(&b, r.T("report.master"), r.NodeName(cfg.MasterServerID))
+		slaves := make([]string, 0, len(cfg.SlaveServerIDs))
+		for _, id := range cfg.SlaveServerIDs {
+			slaves = append(slaves, r.NodeName(id))
+		}
+		mdRow(&b, r.T("report.slaves"), strings.Join(slaves, ", "))
+		mdRow(&b, r.T("report.masterPath"), cfg.RemotePath)
+		mdRow(&b, r.T("report.slavePath"), cfg.SlaveRemotePath)
+		if len(cfg.Commands) > 0 {
+			b.WriteString("\n" + mdCodeBlock("sh", strings.Join(cfg.Commands, "\n")))
+		}
+	}
+
+	if len(run.Steps) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", r.T("report.timeline"))
+		mdHeader(&b, r.T("report.phase"), r.T("report.node"), r.T("report.step"), r.T("report.start"), r.T("report.duration"), r.T("report.result"))
+		for _, st := range run.Steps {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				st.Phase, mdEscape(r.NodeName(st.NodeID)), mdEscape(st.Name), st.StartedAt, formatDuration(st.DurationMs), r.stepResult(st))
+		}
+		for _, st := range run.Steps {
+			if st.Output == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s · %s\n\n%s", mdEscape(st.Name), mdEscape(r.NodeName(st.NodeID)), mdCodeBlock("", strings.TrimRight(st.Output, "\n")))
+		}
+	}
+
+	if len(r.Logs) > 0 {
+		b.WriteString("\n## " + r.T("report.logs") + "\n\n" + mdCodeBlock("", strings.Join(r.Logs, "\n")))
+	}
+	return []byte(b.String())
+}
+
+func mdHeader(b *strings.Builder, columns ...string) {
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n|" + strings.Repeat(" --- |", len(columns)) + "\n")
+}
+
+func mdRow(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "| %s | %s |\n", key, mdEscape(value))
+}
+
+func mdEscape(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// mdCodeBlock 生成围栏代码块，围栏长度大于内容中最长的连续反引号，避免输出中的 ``` 提前结束代码块
+func mdCodeBlock(lang, content string) string {
+	longest, run := 0, 0
+	for _, c := range content {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + lang + "\n" + content + "\n" + fence + "\n"
+}
+
+func (r *Report) stepResult(st internal.RunStep) string {
+	if st.Error != "" {
+		return r.T("report.stepError", st.Status, mdEscape(st.Error))
+	}
+	return string(st.Status)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": formatDuration,
+	"join":     strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.T "report.title" .Run.TaskName}}</title>
+<style>
+body{font-family:-apple-system,"Segoe UI","PingFang SC","Microsoft YaHei",sans-serif;margin:32px;color:#1e293b;background:#f8fafc}
+h1{font-size:22px}h2{font-size:16px;margin-top:28px;border-bottom:1px solid #e2e8f0;padding-bottom:6px}
+table{border-collapse:collapse;width:100%;background:#fff;font-size:13px}
+th,td{border:1px solid #e2e8f0;padding:6px 10px;text-align:left;vertical-align:top}
+th{background:#f1f5f9;width:160px}
+pre{background:#0d1117;color:#c9d1d9;padding:12px;border-radius:6px;overflow:auto;font-size:12px;white-space:pre-wrap}
+.success{color:#059669;font-weight:bold}.failed{color:#e11d48;font-weight:bold}.skipped{color:#64748b}
+</style>
+</head>
+<body>
+<h1>{{.T "report.title" .Run.TaskName}}</h1>
+<table>
+<tr><th>{{.T "report.runID"}}</th><td>{{.Run.ID}}</td></tr>
+<tr><th>{{.T "report.status"}}</th><td class="{{.Run.Status}}">{{.Run.Status}}</td></tr>
+{{- if .Run.FailedPhase}}
+<tr><th>{{.T "report.failedPhase"}}</th><td>{{.Run.FailedPhase}}</td></tr>
+{{- end}}
+<tr><th>{{.T "report.startedAt"}}</th><td>{{.Run.StartedAt}}</td></tr>
+<tr><th>{{.T "report.finishedAt"}}</th><td>{{.Run.FinishedAt}}</td></tr>
+<tr><th>{{.T "report.duration"}}</th><td>{{.Duration}}</td></tr>
+{{- if .Run.RetryOf}}
+<tr><th>{{.T "report.retryOf"}}</th><td>{{.Run.RetryOf}}</td></tr>
+{{- end}}
+<tr><th>{{.T "report.svnURL"}}</th><td>{{.ResourceURL}}</td></tr>
+<tr><th>{{.T "report.revision"}}</th><td>{{.Revision}}</td></tr>
+<tr><th>{{.T "report.checksum"}}</th><td>{{.Checksum}}</td></tr>
+<tr><th>{{.T "report.generatedAt"}}</th><td>{{.GeneratedAt}}</td></tr>
+</table>
+{{- with .Config}}
+<h2>{{$.T "report.config"}}</h2>
+<table>
+<tr><th>{{$.T "report.master"}}</th><td>{{$.NodeName .MasterServerID}}</td></tr>
+<tr><th>{{$.T "report.slaves"}}</th><td>{{range $i, $id := .SlaveServerIDs}}{{if $i}}, {{end}}{{$.NodeName $id}}{{end}}</td></tr>
+<tr><th>{{$.T "report.masterPath"}}</th><td>{{.RemotePath}}</td></tr>
+<tr><th>{{$.T "report.slavePath"}}</th><td>{{.SlaveRemotePath}}</td></tr>
+</table>
+{{- if .Commands}}
+<pre>{{join .Commands "\n"}}</pre>
+{{- end}}
+{{- end}}
+{{- if .Run.Steps}}
+<h2>{{.T "report.timeline"}}</h2>
+<table>
+<tr><th>{{.T "report.phase"}}</th><th>{{.T "report.node"}}</th><th>{{.T "report.step"}}</th><th>{{.T "report.start"}}</th><th>{{.T "report.duration"}}</th><th>{{.T "report.result"}}</th></tr>
+{{- range .Run.Steps}}
+<tr><td>{{.Phase}}</td><td>{{$.NodeName .NodeID}}</td><td>{{.Name}}</td><td>{{.StartedAt}}</td><td>{{duration .DurationMs}}</td><td class="{{.Status}}">{{if .Error}}{{$.T "report.stepError" .Status .Error}}{{else}}{{.Status}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- range .Run.Steps}}
+{{- if .Output}}
+<h2>{{.Name}} · {{$.NodeName .NodeID}}</h2>
+<pre>{{.Output}}</pre>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Logs}}
+<h2>{{.T "report.logs"}}</h2>
+<pre>{{join .Logs "\n"}}</pre>
+{{- end}}
+</body>
+</html>
+`))
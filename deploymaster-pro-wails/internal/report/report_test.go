@@ -0,0 +1,141 @@
+package report
+
+import (
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/i18n"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleReport() *Report {
+	run := &internal.TaskRun{
+		ID:          "run-1",
+		TaskID:      "task-1",
+		TaskName:    "部署 <Web>",
+		Status:      internal.TaskStatusFailed,
+		StartedAt:   "2024-05-01 10:00:00",
+		FinishedAt:  "2024-05-01 10:01:30",
+		FailedPhase: internal.RunPhaseExecute,
+		SVNRevision: "4821",
+		Checkpoint:  &internal.RunCheckpoint{Checksum: "abc123"},
+		Steps: []internal.RunStep{
+			{Phase: internal.RunPhaseExport, Name: "导出 SVN 资源", Status: internal.RunStepSuccess, DurationMs: 1200},
+			{Phase: internal.RunPhaseExecute, NodeID: "n1", Name: "执行命令", Status: internal.RunStepFailed, Output: "$ ls | wc\n3", Error: "exit 1"},
+		},
+	}
+	def := &internal.TaskDefinition{ID: "task-1", MasterServerID: "n1", SlaveServerIDs: []string{"gone"}, Commands: []string{"ls | wc"}}
+	resource := &internal.SVNResource{URL: "svn://repo/app"}
+	nodes := []*internal.Node{{ID: "n1", Name: "web-1", IP: "10.0.0.1"}}
+	return New(run, def, resource, nodes, []string{"line 1"}, i18n.LangZH)
+}
+
+func TestRender(t *testing.T) {
+	r := sampleReport()
+
+	t.Run("HTML", func(t *testing.T) {
+		out, err := Render(r, FormatHTML)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		html := string(out)
+		for _, want := range []string{"部署 &lt;Web&gt;", "svn://repo/app", "4821", "abc123", "web-1 (10.0.0.1)", "exit 1", "1m30s"} {
+			if !strings.Contains(html, want) {
+				t.Errorf("HTML report missing %q", want)
+			}
+		}
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		out, err := Render(r, FormatMarkdown)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		md := string(out)
+		if !strings.Contains(md, "| 修订号 | 4821 |") || !strings.Contains(md, "| 从机 | gone |") {
+			t.Errorf("Unexpected markdown:\n%s", md)
+		}
+		if !strings.Contains(md, "```\n$ ls | wc\n3\n```") {
+			t.Errorf("Markdown should keep command output verbatim:\n%s", md)
+		}
+	})
+
+	t.Run("MarkdownFence", func(t *testing.T) {
+		if got := mdCodeBlock("", "a\n```\nb"); got != "````\na\n```\nb\n````\n" {
+			t.Errorf("Expected a longer fence, got %q", got)
+		}
+		if got := mdCodeBlock("sh", "ls"); got != "```sh\nls\n```\n" {
+			t.Errorf("Unexpected code block %q", got)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := Render(r, FormatJSON)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		var decoded Report
+		if err := json.Unmarshal(out, &decoded); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+		if decoded.Run.ID != "run-1" || len(decoded.Run.Steps) != 2 || len(decoded.Logs) != 1 {
+			t.Errorf("Unexpected decoded report: %+v", decoded)
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		withSnapshot := sampleReport()
+		run := *withSnapshot.Run
+		run.Snapshot = &internal.RunSnapshot{
+			Request:     internal.TaskRunRequest{MasterServerID: "old", RemotePath: "/srv/app"},
+			ResourceURL: "svn://repo/app/tags/1.0",
+			Master:      &internal.Node{ID: "old", Name: "已删除主控", IP: "10.0.0.9"},
+		}
+		withSnapshot.Run = &run
+		out, err := Render(withSnapshot, FormatMarkdown)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		md := string(out)
+		for _, want := range []string{"svn://repo/app/tags/1.0", "| 主控节点 | 已删除主控 (10.0.0.9) |", "| 主控机路径 | /srv/app |"} {
+			if !strings.Contains(md, want) {
+				t.Errorf("Markdown should use snapshot, missing %q:\n%s", want, md)
+			}
+		}
+	})
+
+	t.Run("English", func(t *testing.T) {
+		en := sampleReport()
+		en.Lang = i18n.LangEN
+		out, err := Render(en, FormatMarkdown)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		md := string(out)
+		for _, want := range []string{"# Execution report: ", "| Revision | 4821 |", "| failed: exit 1 |"} {
+			if !strings.Contains(md, want) {
+				t.Errorf("Markdown missing %q:\n%s", want, md)
+			}
+		}
+		if strings.Contains(md, "修订号") {
+			t.Errorf("English report should not contain Chinese labels:\n%s", md)
+		}
+
+		out, err = Render(en, FormatHTML)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if html := string(out); !strings.Contains(html, `<html lang="en-US">`) || !strings.Contains(html, "<th>Checksum (sha256)</th>") {
+			t.Errorf("Unexpected English HTML:\n%s", html)
+		}
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		if f, err := ParseFormat("MD"); err != nil || f != FormatMarkdown || f.Ext() != ".md" {
+			t.Errorf("Unexpected format: %v (%v)", f, err)
+		}
+		if _, err := ParseFormat("pdf"); err != ErrUnsupportedFormat {
+			t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+}
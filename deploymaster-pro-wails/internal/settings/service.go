@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/i18n"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 默认运行历史保留策略；限制条数时至少保留 MinRunsPerTask 条，避免重试来源被清理
+// 按天数清理默认关闭，需用户显式开启
+const (
+	DefaultMaxRunsPerTask = 100
+	DefaultMaxAgeDays     = 0
+	MinRunsPerTask        = 5
+)
+
+// DefaultHeartbeatSeconds 默认节点心跳间隔
+const DefaultHeartbeatSeconds = 60
+
+// DefaultTelemetrySeconds 默认主控机资源采集间隔
+const DefaultTelemetrySeconds = 60
+
+// 默认 SSH 保活：每 15 秒一次，连续 3 次未响应即断开
+const (
+	DefaultKeepAliveSeconds = 15
+	DefaultKeepAliveMissed  = 3
+)
+
+// Service 应用设置服务
+// 存储文件名：settings.json，与节点/任务数据放在同一数据目录
+type Service struct {
+	filePath string
+	settings internal.AppSettings
+	mu       sync.RWMutex
+}
+
+// NewService 创建设置服务并加载已有设置
+func NewService(dataDir string) (*Service, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		filePath: filepath.Join(dataDir, "settings.json"),
+		settings: Defaults(),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Defaults 返回默认设置
+func Defaults() internal.AppSettings {
+	return internal.AppSettings{
+		RunRetention: internal.RunRetention{
+			MaxRunsPerTask: DefaultMaxRunsPerTask,
+			MaxAgeDays:     DefaultMaxAgeDays,
+		},
+		StorageBackend: internal.StorageJSON,
+		Language:       string(i18n.Default),
+		SSHKeepAlive: internal.SSHKeepAlive{
+			IntervalSeconds: DefaultKeepAliveSeconds,
+			MaxMissed:       DefaultKeepAliveMissed,
+		},
+		HeartbeatSeconds: DefaultHeartbeatSeconds,
+		Telemetry: internal.Telemetry{
+			IntervalSeconds: DefaultTelemetrySeconds,
+			DiskPaths:       []string{"/", "/tmp"},
+		},
+	}
+}
+
+func (s *Service) load() error {
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// 在默认值基础上解析，缺失字段保持默认
+	settings := Defaults()
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	s.settings = settings
+	return nil
+}
+
+// Get 获取当前设置
+func (s *Service) Get() internal.AppSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings
+}
+
+// Save 保存设置
+func (s *Service) Save(settings internal.AppSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, s.filePath); err != nil {
+		return err
+	}
+
+	s.settings = settings
+	return nil
+}
@@ -0,0 +1,304 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // 纯 Go SQLite 驱动，无需 cgo
+)
+
+// FileName SQLite 数据库文件名，与 JSON 数据文件放在同一数据目录
+const FileName = "deploymaster.db"
+
+// DB SQLite 数据库封装
+type DB struct {
+	db       *sql.DB
+	filePath string
+}
+
+// migration 数据库迁移步骤，按版本号顺序执行且只执行一次
+type migration struct {
+	version int
+	stmts   []string
+}
+
+// 实体统一以 JSON 文档存储在 data 列，便于模型字段演进；
+// 需要排序或过滤的字段单独建列
+var migrations = []migration{
+	{
+		version: 1,
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS meta (
+				key   TEXT PRIMARY KEY,
+				value TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS nodes (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS svn_resources (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS tasks (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS task_templates (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS task_runs (
+				id         TEXT PRIMARY KEY,
+				position   INTEGER NOT NULL,
+				task_id    TEXT NOT NULL,
+				status     TEXT NOT NULL,
+				started_at TEXT NOT NULL,
+				data       TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_task_runs_task ON task_runs(task_id)`,
+			`CREATE TABLE IF NOT EXISTS run_logs (
+				run_id TEXT NOT NULL,
+				seq    INTEGER NOT NULL,
+				line   TEXT NOT NULL,
+				PRIMARY KEY (run_id, seq)
+			)`,
+		},
+	},
+	{
+		version: 2,
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS topologies (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version: 3,
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS health_history (
+				id       TEXT PRIMARY KEY,
+				position INTEGER NOT NULL,
+				data     TEXT NOT NULL
+			)`,
+		},
+	},
+}
+
+// Open 打开（必要时创建）数据目录下的 SQLite 数据库并执行迁移
+func Open(dataDir string) (*DB, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return OpenFile(filepath.Join(dataDir, FileName))
+}
+
+// OpenFile 打开指定路径的 SQLite 数据库并执行迁移
+func OpenFile(path string) (*DB, error) {
+	dsn := "file:" + filepath.ToSlash(path) + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite 单写者，串行化连接避免 database is locked
+	sqlDB.SetMaxOpenConns(1)
+
+	d := &DB{db: sqlDB, filePath: path}
+	if err := d.migrate(); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// path 返回数据库文件路径
+func (d *DB) path() string {
+	return d.filePath
+}
+
+// Close 关闭数据库
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// SchemaVersion 返回当前 schema 版本
+func (d *DB) SchemaVersion() (int, error) {
+	var version int
+	err := d.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+func (d *DB) migrate() error {
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	current, err := d.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		err := d.inTx(func(tx *sql.Tx) error {
+			for _, stmt := range m.stmts {
+				if _, err := tx.Exec(stmt); err != nil {
+					return err
+				}
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+				m.version, time.Now().Format(time.RFC3339))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d failed: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+func (d *DB) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (d *DB) getMeta(key string) (string, bool, error) {
+	var value string
+	err := d.db.QueryRow(`SELECT value FROM meta WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+func setMeta(tx *sql.Tx, key, value string) error {
+	_, err := tx.Exec(`INSERT INTO meta (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+// table 实体表定义
+type table struct {
+	name      string
+	extraCols []string // data 之外需要单独建列的字段
+	prepend   bool     // 新记录插入在列表头部（任务、运行历史），position 倒序编号
+}
+
+var (
+	tableNodes      = table{name: "nodes"}
+	tableResources  = table{name: "svn_resources"}
+	tableTopologies = table{name: "topologies"}
+	tableHealth     = table{name: "health_history"}
+	tableTasks      = table{name: "tasks", prepend: true}
+	tableTemplates  = table{name: "task_templates", prepend: true}
+	tableRuns       = table{name: "task_runs", extraCols: []string{"task_id", "status", "started_at"}, prepend: true}
+)
+
+// row 待写入的实体行
+type row struct {
+	id    string
+	data  []byte
+	extra []any // 与表的附加列一一对应
+}
+
+// replaceRows 用 rows 整体替换表内容
+// 仅写入内容或位置变化的行并删除已不存在的行，避免每次保存全表重写
+func replaceRows(tx *sql.Tx, t table, rows []row) error {
+	existing := make(map[string]bool)
+	ids, err := tx.Query(`SELECT id FROM ` + t.name)
+	if err != nil {
+		return err
+	}
+	for ids.Next() {
+		var id string
+		if err := ids.Scan(&id); err != nil {
+			_ = ids.Close()
+			return err
+		}
+		existing[id] = true
+	}
+	if err := ids.Close(); err != nil {
+		return err
+	}
+
+	cols := append([]string{"id", "position", "data"}, t.extraCols...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	updates := make([]string, 0, len(cols)-1)
+	for _, c := range cols[1:] {
+		updates = append(updates, c+" = excluded."+c)
+	}
+	upsert, err := tx.Prepare(`INSERT INTO ` + t.name + ` (` + strings.Join(cols, ", ") + `) VALUES (` + placeholders + `)
+		ON CONFLICT(id) DO UPDATE SET ` + strings.Join(updates, ", ") + `
+		WHERE ` + t.name + `.position != excluded.position OR ` + t.name + `.data != excluded.data`)
+	if err != nil {
+		return err
+	}
+	defer upsert.Close()
+
+	for i, r := range rows {
+		position := i
+		if t.prepend {
+			position = len(rows) - 1 - i
+		}
+		args := append([]any{r.id, position, string(r.data)}, r.extra...)
+		if _, err := upsert.Exec(args...); err != nil {
+			return err
+		}
+		delete(existing, r.id)
+	}
+
+	for id := range existing {
+		if _, err := tx.Exec(`DELETE FROM `+t.name+` WHERE id = ?`, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadData 按列表顺序读取表中所有 JSON 文档
+func (d *DB) loadData(t table) ([][]byte, error) {
+	order := "position"
+	if t.prepend {
+		order = "position DESC"
+	}
+	rows, err := d.db.Query(`SELECT data FROM ` + t.name + ` ORDER BY ` + order)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([][]byte, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		result = append(result, []byte(data))
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,154 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"deploymaster-pro-wails/internal/health"
+	"deploymaster-pro-wails/internal/node"
+	"deploymaster-pro-wails/internal/svn"
+	"deploymaster-pro-wails/internal/task"
+	"deploymaster-pro-wails/internal/topology"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// metaJSONImported 标记 JSON 数据已导入
+const metaJSONImported = "json_imported_at"
+
+// ImportResult JSON 导入结果
+type ImportResult struct {
+	Imported   bool // 本次是否执行了导入（已导入过则为 false）
+	Nodes      int
+	Resources  int
+	Tasks      int
+	Templates  int
+	Runs       int
+	Topologies int
+	Health     int // 有心跳历史的节点数
+}
+
+// ImportJSON 一次性导入数据目录下的 nodes.json、svn-resources.json、topologies.json、health.json
+// 与 tasks.json（含 runs/ 下的运行日志）。导入在单个事务中完成，成功后写入标记，
+// 之后再次调用直接返回；原 JSON 文件保留作为备份
+func (d *DB) ImportJSON(dataDir string) (*ImportResult, error) {
+	result := &ImportResult{}
+	if _, done, err := d.getMeta(metaJSONImported); err != nil || done {
+		return result, err
+	}
+
+	nodeStorage, err := node.NewJSONStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := nodeStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	svnStorage, err := svn.NewJSONStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := svnStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	topologyStorage, err := topology.NewJSONStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	topologies, err := topologyStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	healthStorage, err := health.NewJSONStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	histories, err := healthStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	taskStorage, err := task.NewJSONStorage(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	store, err := taskStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// 旧数据可能缺少 ID，导入前补全以免主键冲突
+	for _, n := range nodes {
+		if n.ID == "" {
+			n.ID = uuid.NewString()
+		}
+	}
+	for _, r := range resources {
+		if r.ID == "" {
+			r.ID = uuid.NewString()
+		}
+	}
+
+	// 运行日志：旧版本内联在 tasks.json，新版本存放于 runs/<runID>.log
+	runLogs := make(map[string][]string, len(store.Runs))
+	for _, r := range store.Runs {
+		logs := r.Logs
+		if len(logs) == 0 {
+			if logs, err = taskStorage.LoadRunLogs(r.ID); err != nil {
+				return nil, err
+			}
+		}
+		runLogs[r.ID] = logs
+		r.LogCount = len(logs)
+	}
+
+	err = d.inTx(func(tx *sql.Tx) error {
+		if err := saveNodes(tx, nodes); err != nil {
+			return err
+		}
+		if err := saveSVNResources(tx, resources); err != nil {
+			return err
+		}
+		if err := saveTopologies(tx, topologies); err != nil {
+			return err
+		}
+		if err := saveHealth(tx, histories); err != nil {
+			return err
+		}
+		if err := saveTaskStore(tx, store); err != nil {
+			return err
+		}
+
+		insert, err := tx.Prepare(`INSERT OR REPLACE INTO run_logs (run_id, seq, line) VALUES (?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer insert.Close()
+		for runID, logs := range runLogs {
+			for i, line := range logs {
+				if _, err := insert.Exec(runID, i+1, line); err != nil {
+					return err
+				}
+			}
+		}
+
+		return setMeta(tx, metaJSONImported, time.Now().Format(time.RFC3339))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = true
+	result.Nodes = len(nodes)
+	result.Resources = len(resources)
+	result.Tasks = len(store.Tasks)
+	result.Templates = len(store.Templates)
+	result.Runs = len(store.Runs)
+	result.Topologies = len(topologies)
+	result.Health = len(histories)
+	return result, nil
+}
@@ -0,0 +1,367 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/health"
+	"deploymaster-pro-wails/internal/node"
+	"deploymaster-pro-wails/internal/svn"
+	"deploymaster-pro-wails/internal/task"
+	"deploymaster-pro-wails/internal/topology"
+	"encoding/json"
+	"time"
+)
+
+// 编译期校验接口实现
+var (
+	_ node.Storage     = (*NodeStorage)(nil)
+	_ svn.Storage      = (*SVNStorage)(nil)
+	_ task.Storage     = (*TaskStorage)(nil)
+	_ topology.Storage = (*TopologyStorage)(nil)
+	_ health.Storage   = (*HealthStorage)(nil)
+)
+
+// ===== 节点 =====
+
+// NodeStorage 基于 SQLite 的节点存储实现
+type NodeStorage struct {
+	db     *DB
+	crypto *node.Crypto
+}
+
+// NewNodeStorage 创建节点存储
+// crypto 与 JSON 存储共用数据目录下的 key.txt，保证凭据可继续解密
+func NewNodeStorage(db *DB, crypto *node.Crypto) *NodeStorage {
+	return &NodeStorage{db: db, crypto: crypto}
+}
+
+// Load 加载全部节点
+func (s *NodeStorage) Load() ([]*internal.Node, error) {
+	docs, err := s.db.loadData(tableNodes)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*internal.Node, 0, len(docs))
+	for _, doc := range docs {
+		var n internal.Node
+		if err := json.Unmarshal(doc, &n); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &n)
+	}
+	return nodes, nil
+}
+
+// Save 保存全部节点
+func (s *NodeStorage) Save(nodes []*internal.Node) error {
+	return s.db.inTx(func(tx *sql.Tx) error {
+		return saveNodes(tx, nodes)
+	})
+}
+
+// GetCrypto 获取加密器实例
+func (s *NodeStorage) GetCrypto() *node.Crypto {
+	return s.crypto
+}
+
+func saveNodes(tx *sql.Tx, nodes []*internal.Node) error {
+	rows := make([]row, 0, len(nodes))
+	for _, n := range nodes {
+		data, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: n.ID, data: data})
+	}
+	return replaceRows(tx, tableNodes, rows)
+}
+
+// ===== SVN 资源 =====
+
+// SVNStorage 基于 SQLite 的 SVN 资源存储实现
+type SVNStorage struct {
+	db *DB
+}
+
+// NewSVNStorage 创建 SVN 资源存储
+func NewSVNStorage(db *DB) *SVNStorage {
+	return &SVNStorage{db: db}
+}
+
+// Load 加载全部资源
+func (s *SVNStorage) Load() ([]*internal.SVNResource, error) {
+	docs, err := s.db.loadData(tableResources)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*internal.SVNResource, 0, len(docs))
+	for _, doc := range docs {
+		var r internal.SVNResource
+		if err := json.Unmarshal(doc, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, &r)
+	}
+	return resources, nil
+}
+
+// Save 保存全部资源
+func (s *SVNStorage) Save(resources []*internal.SVNResource) error {
+	return s.db.inTx(func(tx *sql.Tx) error {
+		return saveSVNResources(tx, resources)
+	})
+}
+
+func saveSVNResources(tx *sql.Tx, resources []*internal.SVNResource) error {
+	rows := make([]row, 0, len(resources))
+	for _, r := range resources {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: r.ID, data: data})
+	}
+	return replaceRows(tx, tableResources, rows)
+}
+
+// ===== 拓扑 =====
+
+// TopologyStorage 基于 SQLite 的拓扑存储实现
+type TopologyStorage struct {
+	db *DB
+}
+
+// NewTopologyStorage 创建拓扑存储
+func NewTopologyStorage(db *DB) *TopologyStorage {
+	return &TopologyStorage{db: db}
+}
+
+// Load 加载全部拓扑
+func (s *TopologyStorage) Load() ([]*internal.Topology, error) {
+	docs, err := s.db.loadData(tableTopologies)
+	if err != nil {
+		return nil, err
+	}
+	topologies := make([]*internal.Topology, 0, len(docs))
+	for _, doc := range docs {
+		var t internal.Topology
+		if err := json.Unmarshal(doc, &t); err != nil {
+			return nil, err
+		}
+		topologies = append(topologies, &t)
+	}
+	return topologies, nil
+}
+
+// Save 保存全部拓扑
+func (s *TopologyStorage) Save(topologies []*internal.Topology) error {
+	return s.db.inTx(func(tx *sql.Tx) error {
+		return saveTopologies(tx, topologies)
+	})
+}
+
+func saveTopologies(tx *sql.Tx, topologies []*internal.Topology) error {
+	rows := make([]row, 0, len(topologies))
+	for _, t := range topologies {
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: t.ID, data: data})
+	}
+	return replaceRows(tx, tableTopologies, rows)
+}
+
+// ===== 心跳历史 =====
+
+// HealthStorage 基于 SQLite 的心跳历史存储实现，每个节点一行
+type HealthStorage struct {
+	db *DB
+}
+
+// NewHealthStorage 创建心跳历史存储
+func NewHealthStorage(db *DB) *HealthStorage {
+	return &HealthStorage{db: db}
+}
+
+// Load 加载全部节点的心跳历史
+func (s *HealthStorage) Load() ([]*internal.NodeHealthHistory, error) {
+	docs, err := s.db.loadData(tableHealth)
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]*internal.NodeHealthHistory, 0, len(docs))
+	for _, doc := range docs {
+		var h internal.NodeHealthHistory
+		if err := json.Unmarshal(doc, &h); err != nil {
+			return nil, err
+		}
+		histories = append(histories, &h)
+	}
+	return histories, nil
+}
+
+// Save 保存全部节点的心跳历史
+func (s *HealthStorage) Save(histories []*internal.NodeHealthHistory) error {
+	return s.db.inTx(func(tx *sql.Tx) error {
+		return saveHealth(tx, histories)
+	})
+}
+
+func saveHealth(tx *sql.Tx, histories []*internal.NodeHealthHistory) error {
+	rows := make([]row, 0, len(histories))
+	for _, h := range histories {
+		data, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: h.NodeID, data: data})
+	}
+	return replaceRows(tx, tableHealth, rows)
+}
+
+// ===== 任务 =====
+
+// TaskStorage 基于 SQLite 的任务存储实现
+// 运行日志存放在 run_logs 表，按 seq 追加
+type TaskStorage struct {
+	db *DB
+}
+
+// NewTaskStorage 创建任务存储
+func NewTaskStorage(db *DB) *TaskStorage {
+	return &TaskStorage{db: db}
+}
+
+// Load 加载任务、模板与运行摘要
+func (s *TaskStorage) Load() (*internal.TaskStore, error) {
+	store := &internal.TaskStore{
+		Tasks:     []*internal.TaskDefinition{},
+		Templates: []*internal.TaskTemplate{},
+		Runs:      []*internal.TaskRun{},
+		UpdatedAt: time.Now(),
+	}
+
+	docs, err := s.db.loadData(tableTasks)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		var t internal.TaskDefinition
+		if err := json.Unmarshal(doc, &t); err != nil {
+			return nil, err
+		}
+		store.Tasks = append(store.Tasks, &t)
+	}
+
+	if docs, err = s.db.loadData(tableTemplates); err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		var t internal.TaskTemplate
+		if err := json.Unmarshal(doc, &t); err != nil {
+			return nil, err
+		}
+		store.Templates = append(store.Templates, &t)
+	}
+
+	if docs, err = s.db.loadData(tableRuns); err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		var r internal.TaskRun
+		if err := json.Unmarshal(doc, &r); err != nil {
+			return nil, err
+		}
+		store.Runs = append(store.Runs, &r)
+	}
+
+	return store, nil
+}
+
+// Save 保存任务、模板与运行摘要，并清理已删除运行的日志
+func (s *TaskStorage) Save(store *internal.TaskStore) error {
+	return s.db.inTx(func(tx *sql.Tx) error {
+		return saveTaskStore(tx, store)
+	})
+}
+
+func saveTaskStore(tx *sql.Tx, store *internal.TaskStore) error {
+	rows := make([]row, 0, len(store.Tasks))
+	for _, t := range store.Tasks {
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: t.ID, data: data})
+	}
+	if err := replaceRows(tx, tableTasks, rows); err != nil {
+		return err
+	}
+
+	rows = make([]row, 0, len(store.Templates))
+	for _, t := range store.Templates {
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{id: t.ID, data: data})
+	}
+	if err := replaceRows(tx, tableTemplates, rows); err != nil {
+		return err
+	}
+
+	rows = make([]row, 0, len(store.Runs))
+	for _, r := range store.Runs {
+		summary := *r
+		summary.Logs = nil
+		data, err := json.Marshal(&summary)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row{
+			id:    r.ID,
+			data:  data,
+			extra: []any{r.TaskID, string(r.Status), r.StartedAt},
+		})
+	}
+	if err := replaceRows(tx, tableRuns, rows); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`DELETE FROM run_logs WHERE run_id NOT IN (SELECT id FROM task_runs)`)
+	return err
+}
+
+// AppendRunLog 追加一行运行日志
+func (s *TaskStorage) AppendRunLog(runID, line string) error {
+	_, err := s.db.db.Exec(`INSERT INTO run_logs (run_id, seq, line)
+		VALUES (?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM run_logs WHERE run_id = ?), ?)`,
+		runID, runID, line)
+	return err
+}
+
+// LoadRunLogs 读取运行日志
+func (s *TaskStorage) LoadRunLogs(runID string) ([]string, error) {
+	rows, err := s.db.db.Query(`SELECT line FROM run_logs WHERE run_id = ? ORDER BY seq`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make([]string, 0)
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		logs = append(logs, line)
+	}
+	return logs, rows.Err()
+}
+
+// DeleteRunLogs 删除运行日志
+func (s *TaskStorage) DeleteRunLogs(runID string) error {
+	_, err := s.db.db.Exec(`DELETE FROM run_logs WHERE run_id = ?`, runID)
+	return err
+}
@@ -0,0 +1,181 @@
+package sqlstore
+
+import (
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/health"
+	"deploymaster-pro-wails/internal/node"
+	"deploymaster-pro-wails/internal/svn"
+	"deploymaster-pro-wails/internal/task"
+	"deploymaster-pro-wails/internal/topology"
+	"testing"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	db, err := Open(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	t.Run("Migrations", func(t *testing.T) {
+		version, err := db.SchemaVersion()
+		if err != nil {
+			t.Fatalf("Failed to read schema version: %v", err)
+		}
+		if version != migrations[len(migrations)-1].version {
+			t.Errorf("Expected schema version %d, got %d", migrations[len(migrations)-1].version, version)
+		}
+
+		// 重复打开不应重复执行迁移
+		again, err := OpenFile(db.path())
+		if err != nil {
+			t.Fatalf("Failed to reopen db: %v", err)
+		}
+		_ = again.Close()
+	})
+
+	t.Run("NodeService", func(t *testing.T) {
+		crypto, _ := node.NewCrypto(tmpDir)
+		service, err := node.NewService(NewNodeStorage(db, crypto))
+		if err != nil {
+			t.Fatalf("Failed to create node service: %v", err)
+		}
+		_ = service.AddNode(&internal.Node{ID: "n1", Name: "主控", IP: "10.0.0.1", Port: 22, IsMaster: true})
+		_ = service.AddNode(&internal.Node{ID: "n2", Name: "从机", IP: "10.0.0.2", Port: 22})
+		_ = service.DeleteNode("n1")
+
+		reloaded, _ := node.NewService(NewNodeStorage(db, crypto))
+		nodes := reloaded.ListNodes()
+		if len(nodes) != 1 || nodes[0].ID != "n2" {
+			t.Errorf("Unexpected nodes after reload: %+v", nodes)
+		}
+	})
+
+	t.Run("SVNService", func(t *testing.T) {
+		service, _ := svn.NewService(NewSVNStorage(db))
+		if err := service.AddResource(&internal.SVNResource{ID: "r1", URL: "svn://repo/app", Name: "app"}); err != nil {
+			t.Fatalf("Failed to add resource: %v", err)
+		}
+		reloaded, _ := svn.NewService(NewSVNStorage(db))
+		res, err := reloaded.GetResource("r1")
+		if err != nil || res.Revision != "HEAD" {
+			t.Errorf("Unexpected resource after reload: %+v (%v)", res, err)
+		}
+	})
+
+	t.Run("TopologyService", func(t *testing.T) {
+		service, _ := topology.NewService(NewTopologyStorage(db))
+		_, _ = service.AddTopology(&internal.Topology{ID: "t1", Name: "prod", MasterID: "n1"})
+		_, _ = service.AddTopology(&internal.Topology{ID: "t2", Name: "staging", MasterID: "n2"})
+
+		reloaded, _ := topology.NewService(NewTopologyStorage(db))
+		list := reloaded.ListTopologies()
+		if len(list) != 2 || list[0].ID != "t1" || list[1].Name != "staging" {
+			t.Errorf("Unexpected topologies after reload: %+v", list)
+		}
+	})
+
+	t.Run("HealthHistory", func(t *testing.T) {
+		history, err := health.NewHistory(NewHealthStorage(db), 0)
+		if err != nil {
+			t.Fatalf("Failed to create history: %v", err)
+		}
+		_ = history.Record("n1", &internal.NodeStatus{Status: internal.StatusConnected, Latency: 12})
+		_ = history.Record("n1", &internal.NodeStatus{Status: internal.StatusError})
+		if err := history.Flush(); err != nil {
+			t.Fatalf("Failed to flush history: %v", err)
+		}
+
+		reloaded, _ := health.NewHistory(NewHealthStorage(db), 0)
+		got := reloaded.Health("n1", 0)
+		if got.Checks != 2 || got.Uptime != 50 || got.AvgLatency != 12 {
+			t.Errorf("Unexpected health after reload: %+v", got)
+		}
+	})
+
+	t.Run("TaskServiceAndLogs", func(t *testing.T) {
+		storage := NewTaskStorage(db)
+		service, _ := task.NewService(storage)
+		def, _ := service.AddTask(&internal.TaskDefinition{Name: "部署"})
+		run, _ := service.CreateRun(def.ID, def.Name)
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusDownloading, 5, "line 1")
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusSuccess, 100, "line 2")
+
+		reloaded, _ := task.NewService(storage)
+		got, err := reloaded.GetRun(run.ID)
+		if err != nil || got.Status != internal.TaskStatusSuccess || got.LogCount != 2 {
+			t.Errorf("Unexpected run after reload: %+v (%v)", got, err)
+		}
+		logs, _ := reloaded.GetRunLogs(run.ID)
+		if len(logs) != 2 || logs[0] != "line 1" {
+			t.Errorf("Unexpected logs: %q", logs)
+		}
+
+		if err := reloaded.DeleteTask(def.ID); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+		if logs, _ := storage.LoadRunLogs(run.ID); len(logs) != 0 {
+			t.Errorf("Expected logs to be removed with task, got %q", logs)
+		}
+	})
+}
+
+func TestImportJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// 准备 JSON 数据
+	nodeStorage, _ := node.NewJSONStorage(tmpDir)
+	_ = nodeStorage.Save([]*internal.Node{{ID: "n1", Name: "主控", IsMaster: true}, {Name: "旧节点"}})
+	svnStorage, _ := svn.NewJSONStorage(tmpDir)
+	_ = svnStorage.Save([]*internal.SVNResource{{ID: "r1", URL: "svn://repo/app"}})
+	taskStorage, _ := task.NewJSONStorage(tmpDir)
+	_ = taskStorage.Save(&internal.TaskStore{
+		Tasks: []*internal.TaskDefinition{{ID: "t1", Name: "部署"}},
+		Runs: []*internal.TaskRun{
+			{ID: "run-inline", TaskID: "t1", Logs: []string{"a", "b"}},
+			{ID: "run-file", TaskID: "t1"},
+		},
+	})
+	_ = taskStorage.AppendRunLog("run-file", "c")
+	healthStorage, _ := health.NewJSONStorage(tmpDir)
+	_ = healthStorage.Save([]*internal.NodeHealthHistory{{NodeID: "n1", Samples: []internal.HealthSample{{Time: 1, Status: internal.StatusConnected, Latency: 12}}}})
+
+	db, err := Open(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	result, err := db.ImportJSON(tmpDir)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !result.Imported || result.Nodes != 2 || result.Resources != 1 || result.Tasks != 1 || result.Runs != 2 || result.Health != 1 {
+		t.Errorf("Unexpected import result: %+v", result)
+	}
+
+	nodes, _ := NewNodeStorage(db, nil).Load()
+	if len(nodes) != 2 || nodes[1].ID == "" {
+		t.Errorf("Expected 2 nodes with IDs, got %+v", nodes)
+	}
+
+	store := NewTaskStorage(db)
+	if logs, _ := store.LoadRunLogs("run-inline"); len(logs) != 2 {
+		t.Errorf("Expected inline logs to be imported, got %q", logs)
+	}
+	if logs, _ := store.LoadRunLogs("run-file"); len(logs) != 1 || logs[0] != "c" {
+		t.Errorf("Expected file logs to be imported, got %q", logs)
+	}
+
+	if histories, _ := NewHealthStorage(db).Load(); len(histories) != 1 || histories[0].NodeID != "n1" || len(histories[0].Samples) != 1 {
+		t.Errorf("Expected health history to be imported, got %+v", histories)
+	}
+
+	// 第二次导入不应执行
+	again, err := db.ImportJSON(tmpDir)
+	if err != nil || again.Imported {
+		t.Errorf("Expected import to run only once, got %+v (%v)", again, err)
+	}
+}
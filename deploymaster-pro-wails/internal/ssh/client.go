@@ -1,9 +1,11 @@
 package ssh
 
 import (
+	"deploymaster-pro-wails/internal/i18n"
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/ssh"
@@ -14,6 +16,18 @@ import (
 type Client struct {
 	client *ssh.Client
 	config *ssh.ClientConfig
+
+	jumps []JumpHost    // 跳板链，为空时直连
+	hops  []*ssh.Client // 已建立的跳板连接，按连接顺序排列
+
+	keepAlive KeepAlive
+	mu        sync.Mutex
+	lost      error         // 保活失败后记录的断开原因
+	stop      chan struct{} // 关闭时停止保活
+}
+
+func newClient(config *ssh.ClientConfig) *Client {
+	return &Client{config: config, keepAlive: DefaultKeepAlive}
 }
 
 // NewClient 创建SSH客户端（密码认证）
@@ -27,16 +41,14 @@ func NewClient(username, password string) *Client {
 		Timeout:         10 * time.Second,
 	}
 
-	return &Client{
-		config: config,
-	}
+	return newClient(config)
 }
 
 // NewClientWithKey 使用私钥创建SSH客户端（从字节数组）
 func NewClientWithKey(username string, privateKey []byte) (*Client, error) {
 	signer, err := ssh.ParsePrivateKey(privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("parse private key failed: %w", err)
+		return nil, i18n.Wrap(err, "ssh.parseKeyFailed")
 	}
 
 	config := &ssh.ClientConfig{
@@ -48,9 +60,7 @@ func NewClientWithKey(username string, privateKey []byte) (*Client, error) {
 		Timeout:         10 * time.Second,
 	}
 
-	return &Client{
-		config: config,
-	}, nil
+	return newClient(config), nil
 }
 
 // NewClientWithKeyFile 从文件路径加载私钥创建SSH客户端
@@ -60,7 +70,7 @@ func NewClientWithKeyFile(username, keyPath, passphrase string) (*Client, error)
 	// 读取私钥文件
 	keyBytes, err := os.ReadFile(keyPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read private key file: %w", err)
+		return nil, i18n.Wrap(err, "ssh.readKeyFailed")
 	}
 
 	// 解析私钥
@@ -71,7 +81,7 @@ func NewClientWithKeyFile(username, keyPath, passphrase string) (*Client, error)
 		signer, err = ssh.ParsePrivateKey(keyBytes)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+		return nil, i18n.Wrap(err, "ssh.parseKeyFailed")
 	}
 
 	config := &ssh.ClientConfig{
@@ -83,9 +93,7 @@ func NewClientWithKeyFile(username, keyPath, passphrase string) (*Client, error)
 		Timeout:         10 * time.Second,
 	}
 
-	return &Client{
-		config: config,
-	}, nil
+	return newClient(config), nil
 }
 
 // NewClientWithAgent 使用SSH Agent创建客户端
@@ -93,12 +101,12 @@ func NewClientWithKeyFile(username, keyPath, passphrase string) (*Client, error)
 func NewClientWithAgent(username string) (*Client, error) {
 	socket := os.Getenv("SSH_AUTH_SOCK")
 	if socket == "" {
-		return nil, fmt.Errorf("SSH_AUTH_SOCK environment variable not set")
+		return nil, i18n.New("ssh.agentSockMissing")
 	}
 
 	conn, err := net.Dial("unix", socket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to SSH agent: %w", err)
+		return nil, i18n.Wrap(err, "ssh.agentConnectFailed")
 	}
 
 	agentClient := agent.NewClient(conn)
@@ -112,53 +120,105 @@ func NewClientWithAgent(username string) (*Client, error) {
 		Timeout:         10 * time.Second,
 	}
 
-	return &Client{
-		config: config,
-	}, nil
+	return newClient(config), nil
 }
 
 // Connect 连接到远程服务器
+// 设置了跳板链时逐跳建立连接，目标主机经最后一跳转发
 func (c *Client) Connect(host string, port int) error {
 	addr := fmt.Sprintf("%s:%d", host, port)
+	if len(c.jumps) > 0 {
+		return c.connectViaJumps(addr)
+	}
 
 	client, err := ssh.Dial("tcp", addr, c.config)
 	if err != nil {
-		return fmt.Errorf("ssh dial failed: %w", err)
+		return i18n.Wrap(err, "ssh.dialFailed")
 	}
 
 	c.client = client
+	c.startKeepAlive()
 	return nil
 }
 
-// Close 关闭连接
+// Close 关闭连接，并按相反顺序关闭跳板连接
 func (c *Client) Close() error {
+	c.stopKeepAlive()
+	var err error
 	if c.client != nil {
-		return c.client.Close()
+		err = c.client.Close()
 	}
-	return nil
+	for i := len(c.hops) - 1; i >= 0; i-- {
+		_ = c.hops[i].Close()
+	}
+	c.hops = nil
+	return err
 }
 
-// ExecuteCommand 执行远程命令
+// ExecuteCommand 执行远程命令，返回合并的标准输出与标准错误
+// 设置了 IdleTimeout 时，命令超过该时间无任何输出即中止并结束远程进程；连接因保活失败断开时返回明确的断开错误
 func (c *Client) ExecuteCommand(cmd string) (string, error) {
+	return c.execute(cmd, c.keepAlive.IdleTimeout)
+}
+
+// ExecuteLongCommand 执行运行期间可能长时间无输出的命令（如 syncd 同步），不受 IdleTimeout 限制
+// 连接失效仍由保活检测
+func (c *Client) ExecuteLongCommand(cmd string) (string, error) {
+	return c.execute(cmd, 0)
+}
+
+func (c *Client) execute(cmd string, idle time.Duration) (string, error) {
 	if c.client == nil {
-		return "", fmt.Errorf("not connected")
+		return "", i18n.New("ssh.notConnected")
 	}
 
 	session, err := c.client.NewSession()
 	if err != nil {
-		return "", fmt.Errorf("create session failed: %w", err)
+		if lost := c.lostErr(); lost != nil {
+			return "", lost
+		}
+		return "", i18n.Wrap(err, "ssh.sessionFailed")
 	}
 	defer session.Close()
 
-	output, err := session.CombinedOutput(cmd)
-	if err != nil {
-		return string(output), fmt.Errorf("execute command failed: %w", err)
+	output := &activityBuffer{last: time.Now()}
+	var pid *pidCapture
+	session.Stdout = output
+	session.Stderr = output
+	if idle > 0 {
+		pid = &pidCapture{next: output}
+		session.Stdout = pid
+		cmd = pidPrefix + cmd
+	}
+	if err := session.Start(cmd); err != nil {
+		return "", i18n.Wrap(err, "ssh.executeFailed")
+	}
+
+	if err := c.waitSession(session, output, idle, pid); err != nil {
+		if lost := c.lostErr(); lost != nil {
+			return output.String(), lost
+		}
+		if _, ok := err.(*i18n.Message); ok {
+			return output.String(), err
+		}
+		return output.String(), i18n.Wrap(err, "ssh.executeFailed")
 	}
 
-	return string(output), nil
+	return output.String(), nil
 }
 
 // IsConnected 检查是否已连接
 func (c *Client) IsConnected() bool {
 	return c.client != nil
 }
+
+// Ping 发送 keepalive 请求检查连接是否存活，超过 timeout 未响应视为失效
+func (c *Client) Ping(timeout time.Duration) error {
+	if c.client == nil {
+		return i18n.New("ssh.notConnected")
+	}
+	if lost := c.lostErr(); lost != nil {
+		return lost
+	}
+	return ping(c.client, timeout)
+}
@@ -0,0 +1,79 @@
+package ssh
+
+import (
+	"fmt"
+	"time"
+
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/i18n"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// JumpHost 跳板机：Client 需已配置认证，无需预先连接
+type JumpHost struct {
+	Client *Client
+	Host   string
+	Port   int
+}
+
+// JumpResolver 返回连接节点所需的跳板链，节点无跳板机时返回空
+type JumpResolver func(node *internal.Node) ([]JumpHost, error)
+
+// SetJumpHosts 设置跳板链，Connect 时按顺序逐跳连接：第一跳直连，其后各跳与目标主机经上一跳转发
+func (c *Client) SetJumpHosts(hops ...JumpHost) {
+	c.jumps = hops
+}
+
+func (c *Client) connectViaJumps(addr string) error {
+	hops := make([]*ssh.Client, 0, len(c.jumps))
+	closeHops := func() {
+		for i := len(hops) - 1; i >= 0; i-- {
+			_ = hops[i].Close()
+		}
+	}
+
+	var via *ssh.Client
+	for _, jump := range c.jumps {
+		hopAddr := fmt.Sprintf("%s:%d", jump.Host, jump.Port)
+		hop, err := dialVia(via, hopAddr, jump.Client.config)
+		if err != nil {
+			closeHops()
+			return i18n.Wrap(err, "ssh.jumpFailed", hopAddr)
+		}
+		hops = append(hops, hop)
+		via = hop
+	}
+
+	client, err := dialVia(via, addr, c.config)
+	if err != nil {
+		closeHops()
+		return i18n.Wrap(err, "ssh.dialFailed")
+	}
+	c.client, c.hops = client, hops
+	c.startKeepAlive()
+	return nil
+}
+
+// dialVia 经已建立的连接转发到 addr 并完成 SSH 握手；via 为 nil 时直连
+func dialVia(via *ssh.Client, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if via == nil {
+		return ssh.Dial("tcp", addr, config)
+	}
+
+	conn, err := via.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// 转发通道不支持读写超时，握手超时时直接关闭通道
+	if config.Timeout > 0 {
+		timer := time.AfterFunc(config.Timeout, func() { _ = conn.Close() })
+		defer timer.Stop()
+	}
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(clientConn, chans, reqs), nil
+}
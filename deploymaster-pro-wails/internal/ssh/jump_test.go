@@ -0,0 +1,54 @@
+package ssh
+
+import (
+	"errors"
+	"testing"
+
+	"deploymaster-pro-wails/internal/i18n"
+)
+
+func TestJumpHosts(t *testing.T) {
+	bastionHost, bastionPort := startTestSSHServer(t, "bastion-pass")
+	innerHost, innerPort := startTestSSHServer(t, "inner-pass")
+	targetHost, targetPort := startTestSSHServer(t, "target-pass")
+
+	t.Run("TwoHops", func(t *testing.T) {
+		client := NewClient("deploy", "target-pass")
+		client.SetJumpHosts(
+			JumpHost{Client: NewClient("ops", "bastion-pass"), Host: bastionHost, Port: bastionPort},
+			JumpHost{Client: NewClient("ops", "inner-pass"), Host: innerHost, Port: innerPort},
+		)
+		if err := client.Connect(targetHost, targetPort); err != nil {
+			t.Fatalf("Connect through jump hosts failed: %v", err)
+		}
+		if !client.IsConnected() || len(client.hops) != 2 {
+			t.Errorf("Expected connection with 2 hops, got %d", len(client.hops))
+		}
+		if err := client.Close(); err != nil {
+			t.Errorf("Close failed: %v", err)
+		}
+	})
+
+	t.Run("HopAuthFailed", func(t *testing.T) {
+		client := NewClient("deploy", "target-pass")
+		client.SetJumpHosts(JumpHost{Client: NewClient("ops", "wrong"), Host: bastionHost, Port: bastionPort})
+		err := client.Connect(targetHost, targetPort)
+		var msg *i18n.Message
+		if !errors.As(err, &msg) || msg.Key != "ssh.jumpFailed" {
+			t.Errorf("Expected ssh.jumpFailed, got %v", err)
+		}
+		if client.IsConnected() {
+			t.Error("Client should not be connected")
+		}
+	})
+
+	t.Run("TargetAuthFailed", func(t *testing.T) {
+		client := NewClient("deploy", "wrong")
+		client.SetJumpHosts(JumpHost{Client: NewClient("ops", "bastion-pass"), Host: bastionHost, Port: bastionPort})
+		err := client.Connect(targetHost, targetPort)
+		var msg *i18n.Message
+		if !errors.As(err, &msg) || msg.Key != "ssh.dialFailed" {
+			t.Errorf("Expected ssh.dialFailed, got %v", err)
+		}
+	})
+}
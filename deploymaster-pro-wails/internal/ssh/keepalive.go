@@ -0,0 +1,239 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"deploymaster-pro-wails/internal/i18n"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// KeepAlive 连接保活与空闲检测配置
+type KeepAlive struct {
+	Interval    time.Duration // 保活请求间隔，0 表示不发送
+	MaxMissed   int           // 连续未响应次数达到该值时判定连接失效并断开，0 视为 1
+	IdleTimeout time.Duration // 命令会话无任何输出的最长时间，0 表示不限制
+}
+
+// DefaultKeepAlive 默认每 15 秒保活一次，连续 3 次未响应即断开；命令无输出不限时
+var DefaultKeepAlive = KeepAlive{Interval: 15 * time.Second, MaxMissed: 3}
+
+// SetKeepAlive 设置保活配置，需在 Connect 前调用
+func (c *Client) SetKeepAlive(cfg KeepAlive) {
+	c.keepAlive = cfg
+}
+
+func (c *Client) startKeepAlive() {
+	if c.keepAlive.Interval <= 0 {
+		return
+	}
+	maxMissed := c.keepAlive.MaxMissed
+	if maxMissed <= 0 {
+		maxMissed = 1
+	}
+
+	c.mu.Lock()
+	c.lost = nil
+	c.stop = make(chan struct{})
+	stop := c.stop
+	c.mu.Unlock()
+
+	client, interval := c.client, c.keepAlive.Interval
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		missed := 0
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if err := ping(client, interval); err == nil {
+				missed = 0
+				continue
+			}
+			missed++
+			if missed < maxMissed {
+				continue
+			}
+
+			c.mu.Lock()
+			select {
+			case <-stop:
+				// 已主动关闭
+			default:
+				c.lost = i18n.New("ssh.connectionLost", missed, interval)
+			}
+			c.mu.Unlock()
+			// 关闭底层连接，正在等待的会话与传输随之返回
+			_ = client.Close()
+			return
+		}
+	}()
+}
+
+func (c *Client) stopKeepAlive() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+}
+
+// lostErr 返回保活失败记录的断开原因，连接正常时返回 nil
+func (c *Client) lostErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lost
+}
+
+// LostError 连接已因保活失败断开时以断开原因替换 err，否则原样返回
+// 用于 SFTP、SCP 等直接使用底层连接的路径，避免向用户暴露 EOF 等底层错误
+func (c *Client) LostError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if lost := c.lostErr(); lost != nil {
+		return lost
+	}
+	return err
+}
+
+// ping 发送 keepalive 请求，超过 timeout 未响应视为失败
+func ping(client *ssh.Client, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return i18n.New("ssh.pingTimeout", timeout)
+	}
+}
+
+// pidPrefix 带空闲检测的命令前缀：先输出远程 shell 的进程号
+// sshd 为每个会话调用 setsid，该 shell 即进程组组长，空闲超时时据此结束命令派生的所有进程
+const pidPrefix = "echo $$; "
+
+// waitSession 等待会话结束；idle > 0 时超过该时间无输出则结束远程进程、关闭会话并返回空闲超时错误
+func (c *Client) waitSession(session *ssh.Session, output *activityBuffer, idle time.Duration, pid *pidCapture) error {
+	done := make(chan error, 1)
+	go func() { done <- session.Wait() }()
+
+	if idle <= 0 {
+		return <-done
+	}
+
+	check := idle / 4
+	if check < 10*time.Millisecond {
+		check = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(check)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			if output.idleFor() >= idle {
+				c.killRemote(session, pid.PID())
+				_ = session.Close()
+				return i18n.New("ssh.idleTimeout", idle)
+			}
+		}
+	}
+}
+
+// killRemote 结束空闲超时的远程命令：先向会话发送 KILL 信号，再按进程组结束派生进程
+// 关闭会话本身不会终止远程进程，不处理会遗留孤儿进程
+func (c *Client) killRemote(session *ssh.Session, pid string) {
+	_ = session.Signal(ssh.SIGKILL)
+	if pid == "" {
+		return
+	}
+	kill, err := c.client.NewSession()
+	if err != nil {
+		return
+	}
+	defer kill.Close()
+	_ = kill.Run(fmt.Sprintf("kill -KILL -- -%s 2>/dev/null || kill -KILL %s", pid, pid))
+}
+
+// pidCapture 截取标准输出首行的远程进程号，其余内容写入 next
+type pidCapture struct {
+	mu   sync.Mutex
+	next io.Writer
+	line []byte
+	pid  string
+	done bool
+}
+
+func (p *pidCapture) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return p.next.Write(b)
+	}
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		p.line = append(p.line, b...)
+		return len(b), nil
+	}
+	p.line = append(p.line, b[:i]...)
+	p.done = true
+	if _, err := strconv.Atoi(string(p.line)); err == nil {
+		p.pid = string(p.line)
+	}
+	if rest := b[i+1:]; len(rest) > 0 {
+		if _, err := p.next.Write(rest); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// PID 返回远程进程号，尚未读到时返回空
+func (p *pidCapture) PID() string {
+	if p == nil {
+		return ""
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pid
+}
+
+// activityBuffer 并发安全的输出缓冲，记录最后一次写入时间
+type activityBuffer struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	last time.Time
+}
+
+func (b *activityBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.last = time.Now()
+	return b.buf.Write(p)
+}
+
+func (b *activityBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (b *activityBuffer) idleFor() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Since(b.last)
+}
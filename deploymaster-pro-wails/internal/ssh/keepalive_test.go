@@ -0,0 +1,203 @@
+package ssh
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"deploymaster-pro-wails/internal/i18n"
+)
+
+// freezeProxy TCP 转发代理，freeze 后停止转发但不关闭连接，模拟链路静默中断
+type freezeProxy struct {
+	port   int
+	frozen chan struct{}
+}
+
+func startFreezeProxy(t *testing.T, target string) *freezeProxy {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	p := &freezeProxy{port: ln.Addr().(*net.TCPAddr).Port, frozen: make(chan struct{})}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			upstream, err := net.Dial("tcp", target)
+			if err != nil {
+				_ = conn.Close()
+				continue
+			}
+			go p.pipe(upstream, conn)
+			go p.pipe(conn, upstream)
+		}
+	}()
+	return p
+}
+
+func (p *freezeProxy) pipe(dst io.Writer, src io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		select {
+		case <-p.frozen:
+			return
+		default:
+		}
+		if n > 0 {
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (p *freezeProxy) freeze() {
+	close(p.frozen)
+}
+
+func messageKey(err error) string {
+	var msg *i18n.Message
+	if errors.As(err, &msg) {
+		return msg.Key
+	}
+	return ""
+}
+
+func TestKeepAlive(t *testing.T) {
+	host, port := startTestSSHServer(t, "secret")
+
+	t.Run("ExecuteCommand", func(t *testing.T) {
+		client := NewClient("deploy", "secret")
+		if err := client.Connect(host, port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+
+		output, err := client.ExecuteCommand("echo ping")
+		if err != nil || strings.TrimSpace(output) != "ping" {
+			t.Errorf("Unexpected output %q (%v)", output, err)
+		}
+	})
+
+	t.Run("IdleTimeout", func(t *testing.T) {
+		client := NewClient("deploy", "secret")
+		client.SetKeepAlive(KeepAlive{IdleTimeout: 100 * time.Millisecond})
+		if err := client.Connect(host, port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+
+		started := time.Now()
+		_, err := client.ExecuteCommand("sleep 5s")
+		if key := messageKey(err); key != "ssh.idleTimeout" {
+			t.Fatalf("Expected ssh.idleTimeout, got %v", err)
+		}
+		if time.Since(started) > 2*time.Second {
+			t.Errorf("Idle timeout took too long: %v", time.Since(started))
+		}
+		select {
+		case args := <-testKills:
+			if !strings.Contains(args, "-"+strconv.Itoa(testRemotePID)) {
+				t.Errorf("Expected the remote process group to be killed, got kill %s", args)
+			}
+		case <-time.After(time.Second):
+			t.Error("Expected the remote process to be killed on idle timeout")
+		}
+
+		output, err := client.ExecuteCommand("echo hello")
+		if err != nil || output != "hello\n" {
+			t.Errorf("Expected the pid line to be stripped, got %q, %v", output, err)
+		}
+	})
+
+	t.Run("LongCommandIgnoresIdle", func(t *testing.T) {
+		client := NewClient("deploy", "secret")
+		client.SetKeepAlive(KeepAlive{IdleTimeout: 50 * time.Millisecond})
+		if err := client.Connect(host, port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.ExecuteLongCommand("sleep 300ms"); err != nil {
+			t.Errorf("Expected silent long command to finish, got %v", err)
+		}
+	})
+
+	t.Run("ConnectionLost", func(t *testing.T) {
+		proxy := startFreezeProxy(t, net.JoinHostPort(host, strconv.Itoa(port)))
+		client := NewClient("deploy", "secret")
+		client.SetKeepAlive(KeepAlive{Interval: 50 * time.Millisecond, MaxMissed: 2})
+		if err := client.Connect("127.0.0.1", proxy.port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.ExecuteCommand("sleep 10s")
+			done <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+		proxy.freeze()
+
+		select {
+		case err := <-done:
+			if key := messageKey(err); key != "ssh.connectionLost" {
+				t.Errorf("Expected ssh.connectionLost, got %v", err)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatal("ExecuteCommand hung after the connection went silent")
+		}
+		if key := messageKey(client.Ping(time.Second)); key != "ssh.connectionLost" {
+			t.Errorf("Expected Ping to report the lost connection, got key %q", key)
+		}
+	})
+
+	t.Run("ConnectionLostDuringUpload", func(t *testing.T) {
+		local := filepath.Join(t.TempDir(), "big.bin")
+		if err := os.WriteFile(local, make([]byte, 64<<20), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		proxy := startFreezeProxy(t, net.JoinHostPort(host, strconv.Itoa(port)))
+		client := NewClient("deploy", "secret")
+		client.SetKeepAlive(KeepAlive{Interval: 50 * time.Millisecond, MaxMissed: 2})
+		if err := client.Connect("127.0.0.1", proxy.port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+
+		var once sync.Once
+		done := make(chan error, 1)
+		go func() {
+			done <- client.UploadPathSCP(local, "/srv/big.bin", func(written, _ int64) {
+				once.Do(proxy.freeze)
+			})
+		}()
+
+		select {
+		case err := <-done:
+			if key := messageKey(err); key != "ssh.connectionLost" {
+				t.Errorf("Expected ssh.connectionLost, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Upload hung after the connection went silent")
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package ssh
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// pingTimeout 池中连接健康检查的超时时间
+const pingTimeout = 5 * time.Second
+
+// ErrPoolClosed 连接池已关闭
+var ErrPoolClosed = errors.New("ssh pool closed")
+
+// Pool 按节点复用已认证的 SSH 连接，生命周期为一次任务运行
+// 借出的连接由 Pool 统一关闭，调用方不要 Close；ssh 连接可被多个 goroutine 同时使用
+// 连接保活由 Client 自身的 KeepAlive 负责，Pool 只在借出前做一次健康检查
+type Pool struct {
+	mu      sync.Mutex
+	entries map[string]*poolEntry
+	closed  bool
+
+	dials  int // 新建连接次数
+	reuses int // 复用连接次数
+}
+
+type poolEntry struct {
+	mu     sync.Mutex // 串行化同一节点的建连，避免并发重复握手
+	client *Client
+}
+
+// NewPool 创建连接池
+func NewPool() *Pool {
+	return &Pool{entries: make(map[string]*poolEntry)}
+}
+
+// Get 返回 key 对应的连接；池中连接健康检查失败时关闭并调用 dial 重新建立
+// dial 需返回已连接的客户端
+func (p *Pool) Get(key string, dial func() (*Client, error)) (*Client, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	entry, ok := p.entries[key]
+	if !ok {
+		entry = &poolEntry{}
+		p.entries[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.client != nil {
+		if err := entry.client.Ping(pingTimeout); err == nil {
+			p.count(&p.reuses)
+			return entry.client, nil
+		}
+		_ = entry.client.Close()
+		entry.client = nil
+	}
+
+	client, err := dial()
+	if err != nil {
+		return nil, err
+	}
+
+	// 建连期间连接池可能已关闭
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		_ = client.Close()
+		return nil, ErrPoolClosed
+	}
+	entry.client = client
+	p.count(&p.dials)
+	return client, nil
+}
+
+func (p *Pool) count(n *int) {
+	p.mu.Lock()
+	*n++
+	p.mu.Unlock()
+}
+
+// Stats 返回新建连接数与复用次数
+func (p *Pool) Stats() (dials, reuses int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.dials, p.reuses
+}
+
+// Close 关闭所有连接
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	entries := p.entries
+	p.entries = make(map[string]*poolEntry)
+	p.mu.Unlock()
+
+	for _, entry := range entries {
+		entry.mu.Lock()
+		if entry.client != nil {
+			_ = entry.client.Close()
+			entry.client = nil
+		}
+		entry.mu.Unlock()
+	}
+	return nil
+}
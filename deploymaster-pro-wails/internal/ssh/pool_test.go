@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	host, port := startTestSSHServer(t, "secret")
+	dial := func() (*Client, error) {
+		client := NewClient("deploy", "secret")
+		if err := client.Connect(host, port); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	t.Run("Reuse", func(t *testing.T) {
+		pool := NewPool()
+		defer pool.Close()
+
+		first, err := pool.Get("master", dial)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		second, err := pool.Get("master", dial)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if first != second {
+			t.Error("Expected the pooled connection to be reused")
+		}
+		if _, err := pool.Get("slave", dial); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if dials, reuses := pool.Stats(); dials != 2 || reuses != 1 {
+			t.Errorf("Expected 2 dials and 1 reuse, got %d/%d", dials, reuses)
+		}
+	})
+
+	t.Run("ReconnectDead", func(t *testing.T) {
+		pool := NewPool()
+		defer pool.Close()
+
+		first, _ := pool.Get("master", dial)
+		_ = first.client.Close()
+		second, err := pool.Get("master", dial)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if first == second {
+			t.Error("Expected a new connection after the pooled one died")
+		}
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		pool := NewPool()
+		client, _ := pool.Get("master", dial)
+		if err := pool.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if client.Ping(time.Second) == nil {
+			t.Error("Expected pooled connection to be closed")
+		}
+		if _, err := pool.Get("master", dial); !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("Expected ErrPoolClosed, got %v", err)
+		}
+	})
+}
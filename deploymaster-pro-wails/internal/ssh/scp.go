@@ -0,0 +1,239 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"deploymaster-pro-wails/internal/i18n"
+	"deploymaster-pro-wails/internal/transfer"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+)
+
+// UploadPathSCP 通过 SCP 上传本地路径到远端，用于禁用了 SFTP 子系统的主机
+// 语义与 UploadPath 一致：localPath 为目录时其内容写入 remotePath（自动创建），为文件时写入 remotePath
+// progress 按整个上传的累计字节数回调，可为 nil
+func (c *Client) UploadPathSCP(localPath, remotePath string, progress transfer.ProgressFunc) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		var total int64
+		if progress != nil {
+			if total, err = scpDirSize(localPath); err != nil {
+				return err
+			}
+		}
+		cmd := fmt.Sprintf("mkdir -p %s && scp -r -t %s", shellescape.Quote(remotePath), shellescape.Quote(remotePath))
+		return c.runSCP(cmd, func(s *scpSink) error {
+			s.progress, s.total = progress, total
+			return s.sendDirContents(localPath)
+		})
+	}
+
+	return c.runSCP(scpFileCommand(remotePath), func(s *scpSink) error {
+		s.progress, s.total = progress, info.Size()
+		return s.sendFile(localPath, path.Base(remotePath), info)
+	})
+}
+
+// WriteFileSCP 通过 SCP 写入远程文件
+func (c *Client) WriteFileSCP(remotePath string, data []byte, mode fs.FileMode) error {
+	return c.runSCP(scpFileCommand(remotePath), func(s *scpSink) error {
+		return s.send(path.Base(remotePath), mode, int64(len(data)), bytes.NewReader(data))
+	})
+}
+
+func scpFileCommand(remotePath string) string {
+	dir := path.Dir(remotePath)
+	return fmt.Sprintf("mkdir -p %s && scp -t %s", shellescape.Quote(dir), shellescape.Quote(remotePath))
+}
+
+// runSCP 在远端启动 scp 接收端并通过 send 发送文件
+func (c *Client) runSCP(cmd string, send func(s *scpSink) error) error {
+	if c.client == nil {
+		return i18n.New("ssh.notConnected")
+	}
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		if lost := c.lostErr(); lost != nil {
+			return lost
+		}
+		return i18n.Wrap(err, "ssh.sessionFailed")
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+
+	if err := session.Start(cmd); err != nil {
+		return i18n.Wrap(err, "ssh.executeFailed")
+	}
+
+	sink := newSCPSink(stdin, stdout)
+	sendErr := sink.ack()
+	if sendErr == nil {
+		sendErr = send(sink)
+	}
+	_ = stdin.Close()
+	waitErr := session.Wait()
+
+	if lost := c.lostErr(); lost != nil && (sendErr != nil || waitErr != nil) {
+		return lost
+	}
+	if sendErr != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", sendErr, msg)
+		}
+		return sendErr
+	}
+	if waitErr != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return i18n.New("ssh.scpRejected", msg)
+		}
+		return i18n.Wrap(waitErr, "ssh.executeFailed")
+	}
+	return nil
+}
+
+// scpSink SCP 发送端协议（对端为 scp -t）
+// 每条控制消息与文件内容发送后，接收端回复 1 字节应答：0 成功，1 警告，2 错误（后跟一行消息）
+type scpSink struct {
+	w io.Writer
+	r *bufio.Reader
+
+	progress transfer.ProgressFunc // 可为 nil
+	total    int64                 // 本次发送的总字节数
+	done     int64                 // 已发送完成的文件字节数
+}
+
+func newSCPSink(w io.Writer, r io.Reader) *scpSink {
+	return &scpSink{w: w, r: bufio.NewReader(r)}
+}
+
+func (s *scpSink) ack() error {
+	code, err := s.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if code == 0 {
+		return nil
+	}
+	msg, _ := s.r.ReadString('\n')
+	return i18n.New("ssh.scpRejected", strings.TrimSpace(msg))
+}
+
+// send 发送单个文件：C<mode> <size> <name>，内容，结束符 \0
+func (s *scpSink) send(name string, mode fs.FileMode, size int64, content io.Reader) error {
+	if _, err := fmt.Fprintf(s.w, "C%04o %d %s\n", mode.Perm(), size, name); err != nil {
+		return err
+	}
+	if err := s.ack(); err != nil {
+		return err
+	}
+	var fileProgress transfer.ProgressFunc
+	if s.progress != nil {
+		done := s.done
+		fileProgress = func(written, _ int64) { s.progress(done+written, s.total) }
+	}
+	if _, err := io.CopyN(s.w, transfer.NewProgressReader(content, size, fileProgress), size); err != nil {
+		return err
+	}
+	s.done += size
+	if _, err := s.w.Write([]byte{0}); err != nil {
+		return err
+	}
+	return s.ack()
+}
+
+func (s *scpSink) sendFile(localFile, name string, info fs.FileInfo) error {
+	src, err := os.Open(localFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return s.send(name, info.Mode(), info.Size(), src)
+}
+
+// sendDirContents 递归发送目录内容：子目录以 D/E 消息包裹
+func (s *scpSink) sendDirContents(localDir string) error {
+	entries, err := os.ReadDir(localDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		full := filepath.Join(localDir, entry.Name())
+		// 与 transfer.UploadPath 一致：指向目录的符号链接不跟随，指向文件的按内容发送
+		skip, err := transfer.IsLinkedDir(full, entry)
+		if err != nil {
+			return err
+		}
+		if skip {
+			continue
+		}
+		info, err := os.Stat(full)
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			if err := s.sendFile(full, entry.Name(), info); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(s.w, "D%04o 0 %s\n", info.Mode().Perm(), entry.Name()); err != nil {
+			return err
+		}
+		if err := s.ack(); err != nil {
+			return err
+		}
+		if err := s.sendDirContents(full); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(s.w, "E\n"); err != nil {
+			return err
+		}
+		if err := s.ack(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scpDirSize 统计目录下待发送文件的总字节数，符号链接的处理与 sendDirContents 一致
+func scpDirSize(localDir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if skip, err := transfer.IsLinkedDir(p, d); err != nil || skip {
+			return err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,157 @@
+package ssh
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSCPReceiver 模拟远端 scp -t，将收到的文件写入 root 目录
+func fakeSCPReceiver(t *testing.T, root string, in io.Reader, out io.Writer, reject string) error {
+	t.Helper()
+	r := bufio.NewReader(in)
+	dir := root
+	_, _ = out.Write([]byte{0})
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch line[0] {
+		case 'C':
+			var mode os.FileMode
+			var size int64
+			var name string
+			if _, err := fmt.Sscanf(line, "C%o %d %s", &mode, &size, &name); err != nil {
+				return err
+			}
+			if name == reject {
+				_, _ = out.Write([]byte("\x02" + name + ": Permission denied\n"))
+				continue
+			}
+			_, _ = out.Write([]byte{0})
+			data := make([]byte, size+1)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, name), data[:size], mode); err != nil {
+				return err
+			}
+			_, _ = out.Write([]byte{0})
+		case 'D':
+			var mode os.FileMode
+			var name string
+			if _, err := fmt.Sscanf(line, "D%o 0 %s", &mode, &name); err != nil {
+				return err
+			}
+			dir = filepath.Join(dir, name)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return err
+			}
+			_, _ = out.Write([]byte{0})
+		case 'E':
+			dir = filepath.Dir(dir)
+			_, _ = out.Write([]byte{0})
+		default:
+			return fmt.Errorf("unexpected message %q", line)
+		}
+	}
+}
+
+func TestSCPSink(t *testing.T) {
+	src := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(src, "conf", "nested"), 0755)
+	_ = os.WriteFile(filepath.Join(src, "app.bin"), []byte("binary"), 0755)
+	_ = os.WriteFile(filepath.Join(src, "conf", "app.yaml"), []byte("port: 80\n"), 0644)
+	_ = os.WriteFile(filepath.Join(src, "conf", "nested", "empty.txt"), nil, 0644)
+	// 指向目录的链接（含指回上级的链接环）不跟随，指向文件的链接按内容发送
+	_ = os.Symlink("conf", filepath.Join(src, "conf-link"))
+	_ = os.Symlink("..", filepath.Join(src, "conf", "nested", "loop"))
+	_ = os.Symlink("app.bin", filepath.Join(src, "app-link.bin"))
+
+	run := func(reject string, send func(s *scpSink) error) (string, error) {
+		dst := t.TempDir()
+		toRemote, fromLocal := io.Pipe()
+		fromRemote, toLocal := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			err := fakeSCPReceiver(t, dst, toRemote, toLocal, reject)
+			_ = toLocal.Close()
+			done <- err
+		}()
+
+		sink := newSCPSink(fromLocal, fromRemote)
+		err := sink.ack()
+		if err == nil {
+			err = send(sink)
+		}
+		_ = fromLocal.Close()
+		if recvErr := <-done; recvErr != nil {
+			t.Fatalf("Receiver failed: %v", recvErr)
+		}
+		return dst, err
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		dst, err := run("", func(s *scpSink) error { return s.sendDirContents(src) })
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		for rel, want := range map[string]string{
+			"app.bin":               "binary",
+			"conf/app.yaml":         "port: 80\n",
+			"conf/nested/empty.txt": "",
+			"app-link.bin":          "binary",
+		} {
+			got, err := os.ReadFile(filepath.Join(dst, rel))
+			if err != nil || string(got) != want {
+				t.Errorf("%s: got %q (%v), want %q", rel, got, err, want)
+			}
+		}
+		for _, rel := range []string{"conf-link", "conf/nested/loop"} {
+			if _, err := os.Lstat(filepath.Join(dst, rel)); !os.IsNotExist(err) {
+				t.Errorf("%s: expected linked directory to be skipped, got %v", rel, err)
+			}
+		}
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		total, err := scpDirSize(src)
+		if err != nil || total != int64(2*len("binary")+len("port: 80\n")) {
+			t.Fatalf("Unexpected dir size %d (%v)", total, err)
+		}
+		var last, calls int64
+		_, err = run("", func(s *scpSink) error {
+			s.total = total
+			s.progress = func(written, all int64) {
+				if written < last || all != total {
+					t.Errorf("Unexpected progress %d/%d after %d", written, all, last)
+				}
+				last = written
+				calls++
+			}
+			return s.sendDirContents(src)
+		})
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if calls == 0 || last != total {
+			t.Errorf("Expected progress to reach %d, got %d", total, last)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		_, err := run("app.yaml", func(s *scpSink) error { return s.sendDirContents(src) })
+		if err == nil || !strings.Contains(err.Error(), "Permission denied") {
+			t.Errorf("Expected remote rejection, got %v", err)
+		}
+	})
+}
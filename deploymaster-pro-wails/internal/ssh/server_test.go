@@ -0,0 +1,232 @@
+package ssh
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestSSHServer 启动进程内 SSH 服务器，仅接受指定密码，支持 direct-tcpip 端口转发与简单的 exec 命令
+func startTestSSHServer(t *testing.T, password string) (string, int) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, errors.New("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSH(conn, config)
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func serveTestSSH(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newCh := range chans {
+		if newCh.ChannelType() == "session" {
+			go serveTestSession(newCh)
+			continue
+		}
+		if newCh.ChannelType() != "direct-tcpip" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newCh.ExtraData(), &target); err != nil {
+			_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		upstream, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, chReqs, err := newCh.Accept()
+		if err != nil {
+			_ = upstream.Close()
+			continue
+		}
+		go ssh.DiscardRequests(chReqs)
+		go func() {
+			_, _ = io.Copy(ch, upstream)
+			_ = ch.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(upstream, ch)
+			_ = upstream.Close()
+		}()
+	}
+}
+
+// testRemotePID 测试服务器为带进程号前缀的命令输出的进程号
+const testRemotePID = 4242
+
+// testKills 测试服务器收到的 kill 命令参数
+var testKills = make(chan string, 16)
+
+// discardSCP 作为 scp -t 接收端逐条应答并丢弃收到的文件内容
+func discardSCP(ch ssh.Channel) {
+	r := bufio.NewReader(ch)
+	ack := func() { _, _ = ch.Write([]byte{0}) }
+	ack()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		ack()
+		if line[0] != 'C' {
+			continue
+		}
+		var (
+			mode string
+			size int64
+		)
+		if _, err := fmt.Sscanf(line[1:], "%s %d", &mode, &size); err != nil {
+			return
+		}
+		if _, err := io.CopyN(io.Discard, r, size+1); err != nil {
+			return
+		}
+		ack()
+	}
+}
+
+// serveTestSession 处理 exec 请求：echo <text> 输出文本，sleep <duration> 等待后退出，kill <args> 记录到 testKills；
+// 申请 pty 后的 shell 请求逐行回显输入，"size" 输出当前窗口大小，"exit" 结束会话
+func serveTestSession(newCh ssh.NewChannel) {
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+
+	var (
+		mu         sync.Mutex
+		cols, rows uint32
+	)
+	exit := func() {
+		_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+		_ = ch.Close()
+	}
+	for req := range reqs {
+		switch req.Type {
+		case "pty-req":
+			var pty struct {
+				Term       string
+				Cols, Rows uint32
+				Width      uint32
+				Height     uint32
+				Modes      string
+			}
+			_ = ssh.Unmarshal(req.Payload, &pty)
+			mu.Lock()
+			cols, rows = pty.Cols, pty.Rows
+			mu.Unlock()
+			_ = req.Reply(true, nil)
+		case "window-change":
+			var size struct{ Cols, Rows, Width, Height uint32 }
+			_ = ssh.Unmarshal(req.Payload, &size)
+			mu.Lock()
+			cols, rows = size.Cols, size.Rows
+			mu.Unlock()
+		case "shell":
+			_ = req.Reply(true, nil)
+			go func() {
+				scanner := bufio.NewScanner(ch)
+				for scanner.Scan() {
+					switch line := scanner.Text(); line {
+					case "exit":
+						exit()
+						return
+					case "size":
+						mu.Lock()
+						_, _ = fmt.Fprintf(ch, "%dx%d\r\n", cols, rows)
+						mu.Unlock()
+					default:
+						_, _ = fmt.Fprintf(ch, "%s\r\n", line)
+					}
+				}
+			}()
+		case "exec":
+			var payload struct{ Command string }
+			_ = ssh.Unmarshal(req.Payload, &payload)
+			_ = req.Reply(true, nil)
+
+			command := payload.Command
+			if rest, ok := strings.CutPrefix(command, pidPrefix); ok {
+				_, _ = fmt.Fprintln(ch, testRemotePID)
+				command = rest
+			}
+			name, arg, _ := strings.Cut(command, " ")
+			switch name {
+			case "echo":
+				_, _ = fmt.Fprintln(ch, arg)
+			case "sleep":
+				d, _ := time.ParseDuration(arg)
+				time.Sleep(d)
+			case "kill":
+				select {
+				case testKills <- arg:
+				default:
+				}
+			case "mkdir":
+				// mkdir -p <dir> && scp [-r] -t <path>
+				if strings.Contains(arg, "scp ") {
+					discardSCP(ch)
+				}
+			}
+			exit()
+			return
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
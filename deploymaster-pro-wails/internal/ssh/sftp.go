@@ -1,11 +1,10 @@
 package ssh
 
 import (
-	"fmt"
 	"io"
-	"io/fs"
-	"os"
-	"path/filepath"
+
+	"deploymaster-pro-wails/internal/i18n"
+	"deploymaster-pro-wails/internal/transfer"
 
 	"github.com/pkg/sftp"
 )
@@ -13,67 +12,32 @@ import (
 // NewSFTPClient 创建 SFTP 客户端
 func (c *Client) NewSFTPClient() (*sftp.Client, error) {
 	if c.client == nil {
-		return nil, fmt.Errorf("not connected")
-	}
-	return sftp.NewClient(c.client)
-}
-
-// UploadPath 上传本地路径到远端
-// localPath 可以是文件或目录，remotePath 为目标目录或文件路径
-func UploadPath(client *sftp.Client, localPath, remotePath string) error {
-	info, err := os.Stat(localPath)
-	if err != nil {
-		return err
-	}
-
-	if info.IsDir() {
-		return uploadDir(client, localPath, remotePath)
+		return nil, i18n.New("ssh.notConnected")
 	}
-
-	return uploadFile(client, localPath, remotePath)
+	client, err := sftp.NewClient(c.client)
+	return client, c.LostError(err)
 }
 
-func uploadDir(client *sftp.Client, localDir, remoteDir string) error {
-	if err := client.MkdirAll(remoteDir); err != nil {
-		return err
-	}
-
-	return filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
+// SFTPTransport 返回基于本客户端的 SFTP 传输通道
+// Connect 时按需连接 host:port 并建立 SFTP 会话，Close 时一并关闭 SSH 连接
+func (c *Client) SFTPTransport(host string, port int) *transfer.SFTP {
+	return transfer.NewSFTP(func() (*sftp.Client, io.Closer, error) {
+		if !c.IsConnected() {
+			if err := c.Connect(host, port); err != nil {
+				return nil, nil, err
+			}
 		}
-		rel, err := filepath.Rel(localDir, path)
+		sftpClient, err := c.NewSFTPClient()
 		if err != nil {
-			return err
-		}
-		if rel == "." {
-			return nil
-		}
-		remotePath := filepath.ToSlash(filepath.Join(remoteDir, rel))
-		if d.IsDir() {
-			return client.MkdirAll(remotePath)
+			_ = c.Close()
+			return nil, nil, err
 		}
-		return uploadFile(client, path, remotePath)
+		return sftpClient, c, nil
 	})
 }
 
-func uploadFile(client *sftp.Client, localFile, remoteFile string) error {
-	if err := client.MkdirAll(filepath.ToSlash(filepath.Dir(remoteFile))); err != nil {
-		return err
-	}
-
-	src, err := os.Open(localFile)
-	if err != nil {
-		return err
-	}
-	defer src.Close()
-
-	dst, err := client.Create(remoteFile)
-	if err != nil {
-		return err
-	}
-	defer dst.Close()
-
-	_, err = io.Copy(dst, src)
-	return err
+// UploadPath 上传本地路径到远端
+// localPath 可以是文件或目录，remotePath 为目标目录或文件路径
+func UploadPath(client *sftp.Client, localPath, remotePath string) error {
+	return transfer.UploadPath(transfer.WrapSFTP(client), localPath, remotePath, nil)
 }
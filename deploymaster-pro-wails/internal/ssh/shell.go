@@ -0,0 +1,100 @@
+package ssh
+
+import (
+	"deploymaster-pro-wails/internal/i18n"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// DefaultTerm 交互式会话的终端类型，与 xterm.js 兼容
+const DefaultTerm = "xterm-256color"
+
+// Shell 带伪终端的远程交互式会话
+// Read 返回终端输出（标准输出与标准错误合并），会话结束时返回 io.EOF，连接断开时返回断开原因
+type Shell struct {
+	session *ssh.Session
+	stdin   io.WriteCloser
+	output  *io.PipeReader
+}
+
+// OpenShell 在已连接的客户端上申请 cols x rows 的伪终端并启动登录 shell
+func (c *Client) OpenShell(cols, rows int) (*Shell, error) {
+	if c.client == nil {
+		return nil, i18n.New("ssh.notConnected")
+	}
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		if lost := c.lostErr(); lost != nil {
+			return nil, lost
+		}
+		return nil, i18n.Wrap(err, "ssh.sessionFailed")
+	}
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty(DefaultTerm, rows, cols, modes); err != nil {
+		_ = session.Close()
+		return nil, i18n.Wrap(err, "ssh.ptyFailed")
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, i18n.Wrap(err, "ssh.sessionFailed")
+	}
+	output, writer := io.Pipe()
+	session.Stdout = writer
+	session.Stderr = writer
+	if err := session.Shell(); err != nil {
+		_ = session.Close()
+		return nil, i18n.Wrap(err, "ssh.shellFailed")
+	}
+
+	go func() {
+		err := session.Wait()
+		// shell 以非零状态退出或被 Close 关闭都属正常结束
+		var exitErr *ssh.ExitError
+		var missingErr *ssh.ExitMissingError
+		if errors.As(err, &exitErr) || errors.As(err, &missingErr) {
+			err = nil
+		}
+		if lost := c.lostErr(); lost != nil {
+			err = lost
+		}
+		if err == nil {
+			err = io.EOF
+		}
+		_ = writer.CloseWithError(err)
+	}()
+
+	return &Shell{session: session, stdin: stdin, output: output}, nil
+}
+
+// Read 读取终端输出
+func (s *Shell) Read(p []byte) (int, error) {
+	return s.output.Read(p)
+}
+
+// Write 写入终端输入
+func (s *Shell) Write(p []byte) (int, error) {
+	return s.stdin.Write(p)
+}
+
+// Resize 调整伪终端窗口大小
+func (s *Shell) Resize(cols, rows int) error {
+	return s.session.WindowChange(rows, cols)
+}
+
+// Close 结束会话，Read 随后返回 io.EOF
+func (s *Shell) Close() error {
+	err := s.session.Close()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
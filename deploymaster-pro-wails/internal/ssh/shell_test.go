@@ -0,0 +1,95 @@
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShell(t *testing.T) {
+	host, port := startTestSSHServer(t, "secret")
+	connect := func(t *testing.T) *Client {
+		client := NewClient("deploy", "secret")
+		if err := client.Connect(host, port); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+	readLine := func(t *testing.T, r *bufio.Reader) string {
+		t.Helper()
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		return strings.TrimRight(line, "\r\n")
+	}
+
+	t.Run("EchoAndResize", func(t *testing.T) {
+		shell, err := connect(t).OpenShell(80, 24)
+		if err != nil {
+			t.Fatalf("OpenShell failed: %v", err)
+		}
+		defer shell.Close()
+		r := bufio.NewReader(shell)
+
+		_, _ = shell.Write([]byte("hello\n"))
+		if got := readLine(t, r); got != "hello" {
+			t.Errorf("Expected echo, got %q", got)
+		}
+		_, _ = shell.Write([]byte("size\n"))
+		if got := readLine(t, r); got != "80x24" {
+			t.Errorf("Expected initial size 80x24, got %q", got)
+		}
+
+		if err := shell.Resize(120, 40); err != nil {
+			t.Fatalf("Resize failed: %v", err)
+		}
+		// window-change 无应答，轮询直到服务端生效
+		deadline := time.Now().Add(time.Second)
+		for {
+			_, _ = shell.Write([]byte("size\n"))
+			got := readLine(t, r)
+			if got == "120x40" {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected resized 120x40, got %q", got)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("ExitEndsWithEOF", func(t *testing.T) {
+		shell, err := connect(t).OpenShell(80, 24)
+		if err != nil {
+			t.Fatalf("OpenShell failed: %v", err)
+		}
+		_, _ = shell.Write([]byte("exit\n"))
+		if _, err := io.ReadAll(shell); err != nil {
+			t.Errorf("Expected clean EOF after exit, got %v", err)
+		}
+	})
+
+	t.Run("CloseEndsWithEOF", func(t *testing.T) {
+		shell, err := connect(t).OpenShell(80, 24)
+		if err != nil {
+			t.Fatalf("OpenShell failed: %v", err)
+		}
+		if err := shell.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if _, err := shell.Read(make([]byte, 16)); !errors.Is(err, io.EOF) {
+			t.Errorf("Expected io.EOF after Close, got %v", err)
+		}
+	})
+
+	t.Run("NotConnected", func(t *testing.T) {
+		if _, err := NewClient("deploy", "secret").OpenShell(80, 24); err == nil {
+			t.Error("Expected error without connection")
+		}
+	})
+}
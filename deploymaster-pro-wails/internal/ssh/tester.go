@@ -1,9 +1,15 @@
 package ssh
 
 import (
+	"bufio"
 	"deploymaster-pro-wails/internal"
 	"deploymaster-pro-wails/internal/credential"
-	"fmt"
+	"deploymaster-pro-wails/internal/i18n"
+	"deploymaster-pro-wails/internal/transfer"
+	"io"
+	"net"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,16 +18,37 @@ import (
 type Tester struct {
 	timeout   time.Duration
 	credStore *credential.Store
+	loc       *i18n.Localizer // 错误信息按当前语言渲染
+	jumps     JumpResolver    // 为 nil 时所有节点直连
 }
 
 // NewTester 创建连接测试器
-func NewTester(credStore *credential.Store) *Tester {
+func NewTester(credStore *credential.Store, loc *i18n.Localizer) *Tester {
 	return &Tester{
 		timeout:   10 * time.Second,
 		credStore: credStore,
+		loc:       loc,
 	}
 }
 
+// SetJumpResolver 设置跳板链解析函数，测试连接时经跳板机转发
+func (t *Tester) SetJumpResolver(resolve JumpResolver) {
+	t.jumps = resolve
+}
+
+// applyJumpHosts 为客户端设置节点的跳板链
+func (t *Tester) applyJumpHosts(node *internal.Node, client *Client) error {
+	if t.jumps == nil || node.JumpHostID == "" {
+		return nil
+	}
+	hops, err := t.jumps(node)
+	if err != nil {
+		return err
+	}
+	client.SetJumpHosts(hops...)
+	return nil
+}
+
 // TestConnection 测试单个节点连接
 // 根据节点的认证方式自动选择合适的认证方法
 // 如果node.AuthMethod未设置，则使用传入的username/password进行密码认证
@@ -31,6 +58,19 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 		Status:      internal.StatusTesting,
 	}
 
+	// FTP 节点只验证登录，不执行命令
+	if node.Protocol.IsFTP() {
+		if node.Username != "" {
+			username = node.Username
+		}
+		if t.credStore != nil && node.ID != "" && username != "" {
+			if stored, err := t.credStore.GetPassword(node.ID, username); err == nil && stored != "" {
+				password = stored
+			}
+		}
+		return t.testFTP(node, username, password, status)
+	}
+
 	// 记录开始时间
 	startTime := time.Now()
 
@@ -43,7 +83,7 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 		// SSH密钥认证
 		if node.KeyPath == "" {
 			status.Status = internal.StatusError
-			status.ErrorMsg = "密钥认证模式但未提供密钥路径"
+			status.ErrorMsg = t.loc.T("ssh.keyPathMissing")
 			return status
 		}
 
@@ -56,7 +96,7 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 		client, err = NewClientWithKeyFile(node.Username, node.KeyPath, passphrase)
 		if err != nil {
 			status.Status = internal.StatusError
-			status.ErrorMsg = fmt.Sprintf("创建SSH密钥客户端失败: %v", err)
+			status.ErrorMsg = t.loc.T("ssh.keyClientFailed", t.loc.Error(err))
 			return status
 		}
 
@@ -65,7 +105,7 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 		client, err = NewClientWithAgent(node.Username)
 		if err != nil {
 			status.Status = internal.StatusError
-			status.ErrorMsg = fmt.Sprintf("创建SSH Agent客户端失败: %v", err)
+			status.ErrorMsg = t.loc.T("ssh.agentClientFailed", t.loc.Error(err))
 			return status
 		}
 
@@ -87,10 +127,12 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 	}
 
 	// 尝试连接
-	err = client.Connect(node.IP, node.Port)
+	if err = t.applyJumpHosts(node, client); err == nil {
+		err = client.Connect(node.IP, node.Port)
+	}
 	if err != nil {
 		status.Status = internal.StatusError
-		status.ErrorMsg = fmt.Sprintf("连接失败: %v", err)
+		status.ErrorMsg = t.loc.T("ssh.connectFailed", t.loc.Error(err))
 		return status
 	}
 	defer client.Close()
@@ -102,7 +144,7 @@ func (t *Tester) TestConnection(node *internal.Node, username, password string)
 	_, err = client.ExecuteCommand("echo 'ping'")
 	if err != nil {
 		status.Status = internal.StatusError
-		status.ErrorMsg = fmt.Sprintf("命令执行失败: %v", err)
+		status.ErrorMsg = t.loc.T("ssh.commandFailed", t.loc.Error(err))
 		return status
 	}
 
@@ -122,6 +164,10 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 		Status:      internal.StatusTesting,
 	}
 
+	if node.Protocol.IsFTP() {
+		return t.testFTP(node, username, password, status)
+	}
+
 	startTime := time.Now()
 
 	var client *Client
@@ -131,14 +177,14 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 	case internal.AuthMethodKey:
 		if node.KeyPath == "" {
 			status.Status = internal.StatusError
-			status.ErrorMsg = "密钥认证模式但未提供密钥路径"
+			status.ErrorMsg = t.loc.T("ssh.keyPathMissing")
 			return status
 		}
 
 		client, err = NewClientWithKeyFile(username, node.KeyPath, keyPassphrase)
 		if err != nil {
 			status.Status = internal.StatusError
-			status.ErrorMsg = fmt.Sprintf("密钥认证失败: %v", err)
+			status.ErrorMsg = t.loc.T("ssh.keyAuthFailed", t.loc.Error(err))
 			return status
 		}
 
@@ -146,7 +192,7 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 		client, err = NewClientWithAgent(username)
 		if err != nil {
 			status.Status = internal.StatusError
-			status.ErrorMsg = fmt.Sprintf("Agent认证失败: %v", err)
+			status.ErrorMsg = t.loc.T("ssh.agentAuthFailed", t.loc.Error(err))
 			return status
 		}
 
@@ -154,10 +200,12 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 		client = NewClient(username, password)
 	}
 
-	err = client.Connect(node.IP, node.Port)
+	if err = t.applyJumpHosts(node, client); err == nil {
+		err = client.Connect(node.IP, node.Port)
+	}
 	if err != nil {
 		status.Status = internal.StatusError
-		status.ErrorMsg = fmt.Sprintf("连接失败: %v", err)
+		status.ErrorMsg = t.loc.T("ssh.connectFailed", t.loc.Error(err))
 		return status
 	}
 	defer client.Close()
@@ -167,7 +215,7 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 	_, err = client.ExecuteCommand("echo 'ping'")
 	if err != nil {
 		status.Status = internal.StatusError
-		status.ErrorMsg = fmt.Sprintf("命令执行失败: %v", err)
+		status.ErrorMsg = t.loc.T("ssh.commandFailed", t.loc.Error(err))
 		return status
 	}
 
@@ -178,6 +226,35 @@ func (t *Tester) TestConnectionWithCredentials(node *internal.Node, username, pa
 	return status
 }
 
+// testFTP 登录 FTP/FTPS 节点测试连接，延迟为连接加登录耗时
+func (t *Tester) testFTP(node *internal.Node, username, password string, status *internal.NodeStatus) *internal.NodeStatus {
+	if username == "" {
+		username = "anonymous"
+	}
+
+	startTime := time.Now()
+	ft, err := transfer.DialFTP(transfer.FTPConfig{
+		Host:     node.IP,
+		Port:     node.Port,
+		User:     username,
+		Password: password,
+		TLS:      node.Protocol == internal.ProtocolFTPS,
+		Timeout:  t.timeout,
+	})
+	if err != nil {
+		status.Status = internal.StatusError
+		status.ErrorMsg = t.loc.T("ssh.connectFailed", t.loc.Error(err))
+		return status
+	}
+	latency := time.Since(startTime).Milliseconds()
+	_ = ft.Close()
+
+	status.Status = internal.StatusConnected
+	status.Latency = int(latency)
+	status.ErrorMsg = ""
+	return status
+}
+
 // BatchTestConnections 批量测试多个节点连接
 func (t *Tester) BatchTestConnections(nodes []*internal.Node, username, password string) map[string]*internal.NodeStatus {
 	results := make(map[string]*internal.NodeStatus)
@@ -204,7 +281,8 @@ func (t *Tester) BatchTestConnections(nodes []*internal.Node, username, password
 	return results
 }
 
-// QuickPing 快速Ping测试（仅测试TCP连接，不进行SSH认证）
+// QuickPing 快速探测节点可达性：建立 TCP 连接并读取服务端标识，不进行认证，不会在目标主机产生登录失败记录
+// SSH 节点要求标识以 "SSH-" 开头，FTP 节点要求 220 应答；配置了跳板机时经跳板链转发（使用跳板机自身的凭据）
 func (t *Tester) QuickPing(node *internal.Node) *internal.NodeStatus {
 	status := &internal.NodeStatus{
 		LastChecked: time.Now().Format(time.RFC3339),
@@ -212,25 +290,94 @@ func (t *Tester) QuickPing(node *internal.Node) *internal.NodeStatus {
 	}
 
 	startTime := time.Now()
-
-	// 这里可以使用net.DialTimeout进行TCP连接测试
-	// 暂时使用简单的SSH连接测试
-	// 在实际生产中，可以优化为仅测试TCP端口可达性
-
-	client := NewClient("test", "test") // 临时凭据
-	err := client.Connect(node.IP, node.Port)
-
+	conn, err := t.dialProbe(node)
 	if err != nil {
 		status.Status = internal.StatusDisconnected
-		status.ErrorMsg = fmt.Sprintf("连接不可达: %v", err)
+		status.ErrorMsg = t.loc.T("ssh.unreachable", t.loc.Error(err))
 		return status
 	}
+	defer conn.Close()
 
-	client.Close()
+	expected := "SSH-"
+	if node.Protocol.IsFTP() {
+		expected = "220"
+	}
+	banner, err := readBanner(conn, expected, t.timeout)
+	if err != nil {
+		status.Status = internal.StatusError
+		status.ErrorMsg = t.loc.T("ssh.bannerFailed", t.loc.Error(err))
+		return status
+	}
 	latency := time.Since(startTime).Milliseconds()
 
+	if !strings.HasPrefix(banner, expected) {
+		status.Status = internal.StatusError
+		status.ErrorMsg = t.loc.T("ssh.unexpectedBanner", banner)
+		return status
+	}
+
 	status.Status = internal.StatusConnected
 	status.Latency = int(latency)
-
 	return status
 }
+
+// dialProbe 建立到节点服务端口的 TCP 连接；节点配置了跳板机时经跳板链转发
+func (t *Tester) dialProbe(node *internal.Node) (net.Conn, error) {
+	addr := net.JoinHostPort(node.IP, strconv.Itoa(node.Port))
+	if t.jumps == nil || node.JumpHostID == "" {
+		return net.DialTimeout("tcp", addr, t.timeout)
+	}
+
+	hops, err := t.jumps(node)
+	if err != nil {
+		return nil, err
+	}
+	if len(hops) == 0 {
+		return net.DialTimeout("tcp", addr, t.timeout)
+	}
+	last := hops[len(hops)-1]
+	via := last.Client
+	via.SetJumpHosts(hops[:len(hops)-1]...)
+	if err := via.Connect(last.Host, last.Port); err != nil {
+		return nil, err
+	}
+	conn, err := via.client.Dial("tcp", addr)
+	if err != nil {
+		_ = via.Close()
+		return nil, err
+	}
+	return &tunnelConn{Conn: conn, via: via}, nil
+}
+
+// tunnelConn 经跳板机转发的连接，关闭时一并关闭跳板连接
+type tunnelConn struct {
+	net.Conn
+	via *Client
+}
+
+func (c *tunnelConn) Close() error {
+	err := c.Conn.Close()
+	_ = c.via.Close()
+	return err
+}
+
+// readBanner 读取服务端标识行；SSH 允许在标识前发送其他文本行，最多跳过 5 行
+// 转发通道不支持读超时，超时后直接关闭连接
+func readBanner(conn net.Conn, expected string, timeout time.Duration) (string, error) {
+	timer := time.AfterFunc(timeout, func() { _ = conn.Close() })
+	defer timer.Stop()
+
+	r := bufio.NewReader(io.LimitReader(conn, 4096))
+	var line string
+	for i := 0; i < 5; i++ {
+		raw, err := r.ReadString('\n')
+		line = strings.TrimRight(raw, "\r\n")
+		if err != nil && line == "" {
+			return "", err
+		}
+		if strings.HasPrefix(line, expected) || err != nil {
+			break
+		}
+	}
+	return line, nil
+}
@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"net"
+	"testing"
+
+	"deploymaster-pro-wails/internal"
+	"deploymaster-pro-wails/internal/i18n"
+)
+
+// startBannerServer 启动只发送一行标识后关闭连接的 TCP 服务
+func startBannerServer(t *testing.T, banner string) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(banner + "\r\n"))
+			_ = conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestQuickPing(t *testing.T) {
+	tester := NewTester(nil, i18n.NewLocalizer(i18n.LangEN))
+	host, port := startTestSSHServer(t, "secret")
+
+	t.Run("SSHBanner", func(t *testing.T) {
+		status := tester.QuickPing(&internal.Node{IP: host, Port: port})
+		if status.Status != internal.StatusConnected {
+			t.Errorf("Expected connected, got %s (%s)", status.Status, status.ErrorMsg)
+		}
+	})
+
+	t.Run("FTPBanner", func(t *testing.T) {
+		ftpPort := startBannerServer(t, "220 ftp ready")
+		status := tester.QuickPing(&internal.Node{IP: "127.0.0.1", Port: ftpPort, Protocol: internal.ProtocolFTP})
+		if status.Status != internal.StatusConnected {
+			t.Errorf("Expected connected, got %s (%s)", status.Status, status.ErrorMsg)
+		}
+	})
+
+	t.Run("WrongService", func(t *testing.T) {
+		httpPort := startBannerServer(t, "HTTP/1.1 400 Bad Request")
+		status := tester.QuickPing(&internal.Node{IP: "127.0.0.1", Port: httpPort})
+		if status.Status != internal.StatusError {
+			t.Errorf("Expected error for non-SSH service, got %s", status.Status)
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		ln, _ := net.Listen("tcp", "127.0.0.1:0")
+		closedPort := ln.Addr().(*net.TCPAddr).Port
+		_ = ln.Close()
+		status := tester.QuickPing(&internal.Node{IP: "127.0.0.1", Port: closedPort})
+		if status.Status != internal.StatusDisconnected {
+			t.Errorf("Expected disconnected, got %s", status.Status)
+		}
+	})
+
+	t.Run("ThroughJumpHost", func(t *testing.T) {
+		bastionHost, bastionPort := startTestSSHServer(t, "bastion-pass")
+		jumping := NewTester(nil, i18n.NewLocalizer(i18n.LangEN))
+		jumping.SetJumpResolver(func(*internal.Node) ([]JumpHost, error) {
+			return []JumpHost{{Client: NewClient("ops", "bastion-pass"), Host: bastionHost, Port: bastionPort}}, nil
+		})
+		status := jumping.QuickPing(&internal.Node{IP: host, Port: port, JumpHostID: "bastion"})
+		if status.Status != internal.StatusConnected {
+			t.Errorf("Expected connected through jump host, got %s (%s)", status.Status, status.ErrorMsg)
+		}
+	})
+}
@@ -3,8 +3,7 @@ package svn
 import (
 	"bytes"
 	"context"
-	"errors"
-	"fmt"
+	"deploymaster-pro-wails/internal/i18n"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -28,7 +27,7 @@ func NewClient(timeout time.Duration) *Client {
 func (c *Client) CheckAvailable() error {
 	_, err := exec.LookPath("svn")
 	if err != nil {
-		return errors.New("svn client not found")
+		return i18n.New("svn.clientNotFound")
 	}
 	return nil
 }
@@ -36,7 +35,7 @@ func (c *Client) CheckAvailable() error {
 // Info 获取 SVN 资源信息（当前仅取修订号）
 func (c *Client) Info(ctx context.Context, url, username, password string) (string, error) {
 	if strings.TrimSpace(url) == "" {
-		return "", errors.New("svn url is empty")
+		return "", i18n.New("svn.urlEmpty")
 	}
 
 	if c.timeout > 0 {
@@ -70,12 +69,12 @@ func (c *Client) Info(ctx context.Context, url, username, password string) (stri
 		if msg == "" {
 			msg = err.Error()
 		}
-		return "", fmt.Errorf("svn info failed: %s", msg)
+		return "", i18n.New("svn.infoFailed", msg)
 	}
 
 	rev := strings.TrimSpace(string(output))
 	if rev == "" {
-		return "", errors.New("svn info returned empty revision")
+		return "", i18n.New("svn.emptyRevision")
 	}
 
 	return rev, nil
@@ -84,10 +83,10 @@ func (c *Client) Info(ctx context.Context, url, username, password string) (stri
 // Export 将 SVN 资源导出到目标目录（不包含 .svn 元数据）
 func (c *Client) Export(ctx context.Context, url, username, password, revision, dest string) error {
 	if strings.TrimSpace(url) == "" {
-		return errors.New("svn url is empty")
+		return i18n.New("svn.urlEmpty")
 	}
 	if strings.TrimSpace(dest) == "" {
-		return errors.New("export destination is empty")
+		return i18n.New("svn.destEmpty")
 	}
 
 	if c.timeout > 0 {
@@ -123,7 +122,7 @@ func (c *Client) Export(ctx context.Context, url, username, password, revision,
 		if msg == "" {
 			msg = err.Error()
 		}
-		return fmt.Errorf("svn export failed: %s", msg)
+		return i18n.New("svn.exportFailed", msg)
 	}
 
 	return nil
@@ -132,10 +131,10 @@ func (c *Client) Export(ctx context.Context, url, username, password, revision,
 // CatToFile 将 SVN 文件资源写入到目标文件
 func (c *Client) CatToFile(ctx context.Context, url, username, password, revision, destFile string) error {
 	if strings.TrimSpace(url) == "" {
-		return errors.New("svn url is empty")
+		return i18n.New("svn.urlEmpty")
 	}
 	if strings.TrimSpace(destFile) == "" {
-		return errors.New("export destination is empty")
+		return i18n.New("svn.destEmpty")
 	}
 
 	if c.timeout > 0 {
@@ -182,7 +181,7 @@ func (c *Client) CatToFile(ctx context.Context, url, username, password, revisio
 		if msg == "" {
 			msg = err.Error()
 		}
-		return fmt.Errorf("svn cat failed: %s", msg)
+		return i18n.New("svn.catFailed", msg)
 	}
 
 	return nil
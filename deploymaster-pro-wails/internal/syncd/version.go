@@ -1,3 +1,3 @@
 package syncd
 
-const Version = "1.0.0"
+const Version = "1.4.0"
@@ -0,0 +1,237 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrRunLocked 同一任务或目标路径已有运行
+var ErrRunLocked = errors.New("run locked")
+
+// RunLockKeyTask 任务锁键
+func RunLockKeyTask(taskID string) string {
+	return "task:" + taskID
+}
+
+// RunLockKeyNodePath 节点目标路径锁键，路径先规范化，使 /opt/app/ 与 /opt/app 视为同一目标
+func RunLockKeyNodePath(nodeID, remotePath string) string {
+	return "node:" + nodeID + ":" + path.Clean(remotePath)
+}
+
+// RunLockKeyCache 本地导出缓存锁键
+func RunLockKeyCache(resourceID string) string {
+	return "cache:" + resourceID
+}
+
+// RunLocker 运行锁
+// 按任务、节点目标路径和导出缓存目录加锁，防止同一资源被并发写入
+type RunLocker struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	holders map[string]*RunLease // key -> 持有者
+	leases  []*RunLease          // 按申请顺序排列（含排队中）
+}
+
+// RunLease 运行锁租约
+type RunLease struct {
+	locker *RunLocker
+	state  internal.RunLockState
+	held   bool // 是否实际持有键（allow 模式不持有）
+	queued bool
+	done   bool
+}
+
+// NewRunLocker 创建运行锁
+func NewRunLocker() *RunLocker {
+	l := &RunLocker{holders: make(map[string]*RunLease)}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// Acquire 申请运行锁
+// reject：有冲突时立即返回 ErrRunLocked；
+// queue：有冲突时登记为排队状态，需调用 Wait 等待；
+// allow：不检查冲突，仅登记运行状态
+func (l *RunLocker) Acquire(taskID, taskName string, keys []string, policy internal.RunConcurrency) (*RunLease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lease := &RunLease{
+		locker: l,
+		state: internal.RunLockState{
+			TaskID:   taskID,
+			TaskName: taskName,
+			Keys:     normalizeKeys(keys),
+			State:    "running",
+			Since:    nowString(),
+		},
+	}
+
+	switch policy {
+	case internal.RunConcurrencyAllow:
+	case internal.RunConcurrencyQueue:
+		if l.canHoldLocked(lease) {
+			l.holdLocked(lease)
+		} else {
+			lease.queued = true
+			lease.state.State = "queued"
+		}
+	default:
+		if holder := l.conflictLocked(lease.state.Keys); holder != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRunLocked, describeHolder(holder))
+		}
+		l.holdLocked(lease)
+	}
+
+	l.leases = append(l.leases, lease)
+	return lease, nil
+}
+
+// Active 返回当前运行与排队中的锁状态
+func (l *RunLocker) Active() []*internal.RunLockState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make([]*internal.RunLockState, 0, len(l.leases))
+	for _, lease := range l.leases {
+		state := lease.state
+		state.Keys = append([]string{}, lease.state.Keys...)
+		result = append(result, &state)
+	}
+	return result
+}
+
+// Queued 是否处于排队状态
+func (r *RunLease) Queued() bool {
+	r.locker.mu.Lock()
+	defer r.locker.mu.Unlock()
+	return r.queued
+}
+
+// Bind 绑定运行 ID
+func (r *RunLease) Bind(runID string) {
+	r.locker.mu.Lock()
+	defer r.locker.mu.Unlock()
+	r.state.RunID = runID
+}
+
+// Wait 等待排队结束并持有锁
+// 非排队租约立即返回
+func (r *RunLease) Wait() {
+	l := r.locker
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for r.queued && !r.done {
+		if l.canHoldLocked(r) {
+			l.holdLocked(r)
+			r.queued = false
+			r.state.State = "running"
+			r.state.Since = nowString()
+			l.cond.Broadcast()
+			return
+		}
+		l.cond.Wait()
+	}
+}
+
+// Release 释放锁并唤醒排队中的运行
+func (r *RunLease) Release() {
+	l := r.locker
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r.done {
+		return
+	}
+	r.done = true
+	if r.held {
+		for _, key := range r.state.Keys {
+			if l.holders[key] == r {
+				delete(l.holders, key)
+			}
+		}
+		r.held = false
+	}
+	for i, lease := range l.leases {
+		if lease == r {
+			l.leases = append(l.leases[:i], l.leases[i+1:]...)
+			break
+		}
+	}
+	l.cond.Broadcast()
+}
+
+// canHoldLocked 键均空闲且没有更早排队的冲突租约时可持有（先到先得）
+func (l *RunLocker) canHoldLocked(lease *RunLease) bool {
+	if l.conflictLocked(lease.state.Keys) != nil {
+		return false
+	}
+	for _, other := range l.leases {
+		if other == lease {
+			break
+		}
+		if other.queued && overlaps(other.state.Keys, lease.state.Keys) {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *RunLocker) conflictLocked(keys []string) *RunLease {
+	for _, key := range keys {
+		if holder, ok := l.holders[key]; ok {
+			return holder
+		}
+	}
+	return nil
+}
+
+func (l *RunLocker) holdLocked(lease *RunLease) {
+	for _, key := range lease.state.Keys {
+		l.holders[key] = lease
+	}
+	lease.held = true
+}
+
+func describeHolder(holder *RunLease) string {
+	name := holder.state.TaskName
+	if name == "" {
+		name = holder.state.TaskID
+	}
+	if holder.state.RunID != "" {
+		return fmt.Sprintf("task %s is running (run %s)", name, holder.state.RunID)
+	}
+	return fmt.Sprintf("task %s is running", name)
+}
+
+func normalizeKeys(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
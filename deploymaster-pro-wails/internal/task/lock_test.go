@@ -0,0 +1,96 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunLocker(t *testing.T) {
+	keys := []string{RunLockKeyTask("task-1"), RunLockKeyNodePath("node-1", "/opt/app")}
+
+	t.Run("RejectConflict", func(t *testing.T) {
+		locker := NewRunLocker()
+		first, err := locker.Acquire("task-1", "部署", keys, internal.RunConcurrencyReject)
+		if err != nil {
+			t.Fatalf("Failed to acquire lock: %v", err)
+		}
+
+		// 不同任务但目标路径相同，同样冲突
+		other := []string{RunLockKeyTask("task-2"), RunLockKeyNodePath("node-1", "/opt/app")}
+		if _, err := locker.Acquire("task-2", "", other, ""); !errors.Is(err, ErrRunLocked) {
+			t.Errorf("Expected ErrRunLocked, got %v", err)
+		}
+
+		// 路径写法不同但指向同一目录，同样冲突
+		alias := []string{RunLockKeyTask("task-3"), RunLockKeyNodePath("node-1", "/opt//app/")}
+		if _, err := locker.Acquire("task-3", "", alias, ""); !errors.Is(err, ErrRunLocked) {
+			t.Errorf("Expected ErrRunLocked for an equivalent path, got %v", err)
+		}
+
+		first.Release()
+		second, err := locker.Acquire("task-2", "", other, "")
+		if err != nil {
+			t.Errorf("Expected lock to be free after release, got %v", err)
+		}
+		second.Release()
+	})
+
+	t.Run("QueueWaitsForRelease", func(t *testing.T) {
+		locker := NewRunLocker()
+		first, _ := locker.Acquire("task-1", "", keys, internal.RunConcurrencyReject)
+
+		queued, err := locker.Acquire("task-1", "", keys, internal.RunConcurrencyQueue)
+		if err != nil {
+			t.Fatalf("Failed to queue: %v", err)
+		}
+		if !queued.Queued() {
+			t.Fatal("Expected lease to be queued")
+		}
+
+		acquired := make(chan struct{})
+		go func() {
+			queued.Wait()
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("Queued lease acquired before release")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		first.Release()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("Queued lease not acquired after release")
+		}
+
+		active := locker.Active()
+		if len(active) != 1 || active[0].State != "running" {
+			t.Errorf("Expected 1 running lease, got %+v", active)
+		}
+		queued.Release()
+		if len(locker.Active()) != 0 {
+			t.Error("Expected no active leases")
+		}
+	})
+
+	t.Run("AllowBypassesLock", func(t *testing.T) {
+		locker := NewRunLocker()
+		first, _ := locker.Acquire("task-1", "", keys, internal.RunConcurrencyReject)
+		allowed, err := locker.Acquire("task-1", "", keys, internal.RunConcurrencyAllow)
+		if err != nil {
+			t.Fatalf("Expected allow to succeed, got %v", err)
+		}
+		allowed.Release()
+
+		// allow 租约释放不应影响原持有者
+		if _, err := locker.Acquire("task-1", "", keys, ""); !errors.Is(err, ErrRunLocked) {
+			t.Errorf("Expected original lock to remain, got %v", err)
+		}
+		first.Release()
+	})
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"encoding/json"
+	"strings"
+)
+
+// EncodeLogEntry 将结构化日志编码为单行 JSON，作为日志存储中的一行
+func EncodeLogEntry(entry internal.LogEntry) string {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry.String()
+	}
+	return string(data)
+}
+
+// ParseLogLine 解析日志存储中的一行
+// 新版本为 JSON 编码的 LogEntry；旧版本为 "[时间] [错误] 消息" 形式的文本，按前缀推断级别
+func ParseLogLine(line string) internal.LogEntry {
+	if entry, ok := decodeLogEntry(line); ok {
+		return entry
+	}
+
+	entry := internal.LogEntry{Level: internal.LogLevelInfo, Message: line}
+	if len(line) > len(timeLayout)+2 && line[0] == '[' && line[len(timeLayout)+1] == ']' {
+		if _, ok := parseTime(line[1 : len(timeLayout)+1]); ok {
+			entry.Time = line[1 : len(timeLayout)+1]
+			entry.Message = strings.TrimPrefix(line[len(timeLayout)+2:], " ")
+		}
+	}
+
+	for _, p := range []struct {
+		prefix string
+		level  internal.LogLevel
+	}{
+		{"[错误] ", internal.LogLevelError},
+		{"[警告] ", internal.LogLevelWarn},
+		{"[信息] ", internal.LogLevelInfo},
+		{"✓ ", internal.LogLevelSuccess},
+	} {
+		if strings.HasPrefix(entry.Message, p.prefix) {
+			entry.Level = p.level
+			entry.Message = strings.TrimPrefix(entry.Message, p.prefix)
+			break
+		}
+	}
+	return entry
+}
+
+// displayLine 返回日志行的展示文本，旧版本文本原样返回
+func displayLine(line string) string {
+	if entry, ok := decodeLogEntry(line); ok {
+		return entry.String()
+	}
+	return line
+}
+
+func decodeLogEntry(line string) (internal.LogEntry, bool) {
+	var entry internal.LogEntry
+	if !strings.HasPrefix(line, "{") {
+		return entry, false
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Level == "" {
+		return entry, false
+	}
+	return entry, true
+}
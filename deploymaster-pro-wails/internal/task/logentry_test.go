@@ -0,0 +1,64 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"testing"
+)
+
+func TestParseLogLine(t *testing.T) {
+	t.Run("Legacy", func(t *testing.T) {
+		cases := []struct {
+			line    string
+			level   internal.LogLevel
+			time    string
+			message string
+		}{
+			{"[2024-05-01 10:00:00] [错误] 上传失败", internal.LogLevelError, "2024-05-01 10:00:00", "上传失败"},
+			{"[2024-05-01 10:00:00] [信息] 启动", internal.LogLevelInfo, "2024-05-01 10:00:00", "启动"},
+			{"[2024-05-01 10:00:00] ✓ 任务执行成功", internal.LogLevelSuccess, "2024-05-01 10:00:00", "任务执行成功"},
+			{"plain output", internal.LogLevelInfo, "", "plain output"},
+			{"[not a time] text", internal.LogLevelInfo, "", "[not a time] text"},
+		}
+		for _, c := range cases {
+			entry := ParseLogLine(c.line)
+			if entry.Level != c.level || entry.Time != c.time || entry.Message != c.message {
+				t.Errorf("ParseLogLine(%q) = %+v", c.line, entry)
+			}
+		}
+	})
+
+	t.Run("Structured", func(t *testing.T) {
+		entry := internal.LogEntry{
+			Time:    "2024-05-01 10:00:00",
+			Level:   internal.LogLevelError,
+			Phase:   internal.RunPhaseUpload,
+			NodeID:  "n1",
+			Message: "上传失败",
+			Fields:  map[string]string{"path": "/srv"},
+		}
+		got := ParseLogLine(EncodeLogEntry(entry))
+		if got.NodeID != "n1" || got.Phase != internal.RunPhaseUpload || got.Fields["path"] != "/srv" {
+			t.Errorf("Unexpected round trip: %+v", got)
+		}
+		if got.String() != "[2024-05-01 10:00:00] [错误] 上传失败" {
+			t.Errorf("Unexpected display form: %q", got.String())
+		}
+	})
+
+	t.Run("Service", func(t *testing.T) {
+		storage, _ := NewJSONStorage(t.TempDir())
+		service, _ := NewService(storage)
+		run, _ := service.CreateRun("task-1", "部署")
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusDownloading, 5, "[2024-05-01 10:00:00] [信息] 旧格式")
+		_ = service.AppendRunEntry(run.ID, internal.TaskStatusFailed, 40, internal.LogEntry{Level: internal.LogLevelError, Message: "新格式"})
+
+		entries, err := service.GetRunEntries(run.ID)
+		if err != nil || len(entries) != 2 || entries[1].Level != internal.LogLevelError {
+			t.Errorf("Unexpected entries: %+v (%v)", entries, err)
+		}
+		logs, _ := service.GetRunLogs(run.ID)
+		if len(logs) != 2 || logs[0] != "[2024-05-01 10:00:00] [信息] 旧格式" || logs[1] != "[错误] 新格式" {
+			t.Errorf("Unexpected display logs: %q", logs)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"strings"
+	"time"
+)
+
+// DefaultSearchLimit 检索默认分页大小
+const DefaultSearchLimit = 50
+
+// SearchRuns 按条件检索运行历史，结果按开始时间倒序
+// 先按摘要字段过滤，最后对剩余运行逐个扫描日志做全文匹配
+func (s *Service) SearchRuns(query internal.RunQuery) (*internal.RunQueryResult, error) {
+	match, err := newRunMatcher(query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	candidates := make([]*internal.TaskRun, 0)
+	for _, r := range s.runs {
+		if match.summary(r) {
+			candidates = append(candidates, r)
+		}
+	}
+	s.mu.RUnlock()
+
+	if match.text != "" {
+		filtered := candidates[:0]
+		for _, r := range candidates {
+			logs, err := s.storage.LoadRunLogs(r.ID)
+			if err != nil {
+				return nil, err
+			}
+			if match.logs(logs) {
+				filtered = append(filtered, r)
+			}
+		}
+		candidates = filtered
+	}
+
+	result := &internal.RunQueryResult{Runs: []*internal.TaskRun{}, Total: len(candidates)}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if query.Offset < 0 || query.Offset >= len(candidates) {
+		return result, nil
+	}
+	end := query.Offset + limit
+	if end > len(candidates) {
+		end = len(candidates)
+	}
+	result.Runs = append(result.Runs, candidates[query.Offset:end]...)
+	return result, nil
+}
+
+// runMatcher 预处理后的检索条件
+type runMatcher struct {
+	query    internal.RunQuery
+	from, to time.Time
+	statuses map[internal.TaskStatus]bool
+	revision string
+	text     string
+}
+
+func newRunMatcher(query internal.RunQuery) (*runMatcher, error) {
+	m := &runMatcher{
+		query:    query,
+		revision: normalizeRevision(query.Revision),
+		text:     strings.ToLower(strings.TrimSpace(query.Text)),
+	}
+	if len(query.Statuses) > 0 {
+		m.statuses = make(map[internal.TaskStatus]bool, len(query.Statuses))
+		for _, st := range query.Statuses {
+			m.statuses[st] = true
+		}
+	}
+
+	var err error
+	if m.from, _, err = parseQueryTime(query.From); err != nil {
+		return nil, err
+	}
+	var dateOnly bool
+	if m.to, dateOnly, err = parseQueryTime(query.To); err != nil {
+		return nil, err
+	}
+	if dateOnly {
+		m.to = m.to.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return m, nil
+}
+
+// parseQueryTime 解析检索时间，支持日期或日期时间
+func parseQueryTime(value string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if t, ok := parseTime(value); ok {
+		return t, false, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+// normalizeRevision 去除修订号的 r 前缀
+func normalizeRevision(revision string) string {
+	revision = strings.TrimSpace(revision)
+	if len(revision) > 1 && (revision[0] == 'r' || revision[0] == 'R') {
+		return revision[1:]
+	}
+	return revision
+}
+
+func (m *runMatcher) summary(r *internal.TaskRun) bool {
+	if m.query.TaskID != "" && r.TaskID != m.query.TaskID {
+		return false
+	}
+	if m.statuses != nil && !m.statuses[r.Status] {
+		return false
+	}
+	if !m.from.IsZero() || !m.to.IsZero() {
+		started, ok := parseTime(r.StartedAt)
+		if !ok {
+			return false
+		}
+		if !m.from.IsZero() && started.Before(m.from) {
+			return false
+		}
+		if !m.to.IsZero() && started.After(m.to) {
+			return false
+		}
+	}
+	if m.query.NodeID != "" && !containsString(r.NodeIDs, m.query.NodeID) {
+		return false
+	}
+	if m.revision != "" {
+		// 旧运行记录只在断点信息中保存修订号
+		revision := r.SVNRevision
+		if revision == "" && r.Checkpoint != nil {
+			revision = r.Checkpoint.Revision
+		}
+		if normalizeRevision(revision) != m.revision {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *runMatcher) logs(lines []string) bool {
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(displayLine(line)), m.text) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"testing"
+)
+
+func TestSearchRuns(t *testing.T) {
+	storage, err := NewJSONStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	// 依次创建三次运行，列表中最新的在前
+	old, _ := service.CreateRun("task-web", "部署 Web")
+	_ = service.SetRunTargets(old.ID, []string{"master-1", "web-3"})
+	_ = service.SetRunRevision(old.ID, "4821")
+	_ = service.AppendRunLog(old.ID, internal.TaskStatusSuccess, 100, "同步到 web-3 完成")
+
+	failed, _ := service.CreateRun("task-web", "部署 Web")
+	_ = service.SetRunTargets(failed.ID, []string{"master-1", "web-4"})
+	_ = service.SetRunRevision(failed.ID, "4822")
+	_ = service.AppendRunLog(failed.ID, internal.TaskStatusFailed, 40, "Permission Denied")
+
+	other, _ := service.CreateRun("task-api", "部署 API")
+	_ = service.SetRunTargets(other.ID, []string{"master-2", "web-3"})
+	_ = service.AppendRunLog(other.ID, internal.TaskStatusSuccess, 100, "done")
+
+	search := func(t *testing.T, query internal.RunQuery) *internal.RunQueryResult {
+		t.Helper()
+		result, err := service.SearchRuns(query)
+		if err != nil {
+			t.Fatalf("SearchRuns failed: %v", err)
+		}
+		return result
+	}
+
+	t.Run("NodeAndRevision", func(t *testing.T) {
+		result := search(t, internal.RunQuery{NodeID: "web-3", Revision: "r4821"})
+		if result.Total != 1 || result.Runs[0].ID != old.ID {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("TaskAndStatus", func(t *testing.T) {
+		result := search(t, internal.RunQuery{TaskID: "task-web", Statuses: []internal.TaskStatus{internal.TaskStatusFailed}})
+		if result.Total != 1 || result.Runs[0].ID != failed.ID {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		result := search(t, internal.RunQuery{Text: "permission denied"})
+		if result.Total != 1 || result.Runs[0].ID != failed.ID {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("DateRange", func(t *testing.T) {
+		if result := search(t, internal.RunQuery{To: "2000-01-01"}); result.Total != 0 {
+			t.Errorf("Expected no runs before 2000, got %d", result.Total)
+		}
+		if result := search(t, internal.RunQuery{From: "2000-01-01"}); result.Total != 3 {
+			t.Errorf("Expected 3 runs after 2000, got %d", result.Total)
+		}
+		if _, err := service.SearchRuns(internal.RunQuery{From: "yesterday"}); err == nil {
+			t.Error("Expected error for invalid date")
+		}
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		result := search(t, internal.RunQuery{Offset: 1, Limit: 1})
+		if result.Total != 3 || len(result.Runs) != 1 || result.Runs[0].ID != failed.ID {
+			t.Errorf("Unexpected page: %+v", result)
+		}
+		if result := search(t, internal.RunQuery{Offset: 5}); len(result.Runs) != 0 || result.Total != 3 {
+			t.Errorf("Expected empty page, got %+v", result)
+		}
+	})
+}
@@ -5,8 +5,10 @@ import (
 	"deploymaster-pro-wails/internal"
 	"encoding/hex"
 	"errors"
+	"strconv"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -20,6 +22,8 @@ var (
 	ErrTemplateExists = errors.New("template already exists")
 	// ErrRunNotFound 运行记录不存在
 	ErrRunNotFound = errors.New("run not found")
+	// ErrSnapshotExists 运行配置快照已存在，不允许覆盖
+	ErrSnapshotExists = errors.New("run snapshot already exists")
 )
 
 // Service 任务服务
@@ -29,6 +33,7 @@ type Service struct {
 	tasks     []*internal.TaskDefinition
 	templates []*internal.TaskTemplate
 	runs      []*internal.TaskRun
+	retention internal.RunRetention
 	mu        sync.RWMutex
 }
 
@@ -38,16 +43,62 @@ func NewService(storage Storage) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Service{
+	s := &Service{
 		storage:   storage,
 		tasks:     store.Tasks,
 		templates: store.Templates,
 		runs:      store.Runs,
-	}, nil
+	}
+
+	if err := s.migrateInlineLogs(); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
+// migrateInlineLogs 将旧版本内联在 tasks.json 中的运行日志迁移到独立日志存储
+func (s *Service) migrateInlineLogs() error {
+	changed := false
+	for i, r := range s.runs {
+		if len(r.Logs) == 0 {
+			continue
+		}
+		existing, err := s.storage.LoadRunLogs(r.ID)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			for _, line := range r.Logs {
+				if err := s.storage.AppendRunLog(r.ID, line); err != nil {
+					return err
+				}
+			}
+		}
+		updated := *r
+		updated.LogCount = len(r.Logs)
+		updated.Logs = nil
+		s.runs[i] = &updated
+		changed = true
+	}
+
+	if changed {
+		return s.saveLocked()
+	}
+	return nil
+}
+
+const timeLayout = "2006-01-02 15:04:05"
+
 func nowString() string {
-	return time.Now().Format("2006-01-02 15:04:05")
+	return time.Now().Format(timeLayout)
+}
+
+func parseTime(value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(timeLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
 }
 
 func newID(prefix string) string {
@@ -136,8 +187,11 @@ func (s *Service) UpdateTask(task *internal.TaskDefinition) error {
 		if task.MasterServerID != "" {
 			updated.MasterServerID = task.MasterServerID
 		}
+		// 显式从机列表、选择器与拓扑同属目标配置，一并更新以便清空
 		if task.SlaveServerIDs != nil {
 			updated.SlaveServerIDs = task.SlaveServerIDs
+			updated.SlaveSelector = task.SlaveSelector
+			updated.TopologyID = task.TopologyID
 		}
 		if task.RemotePath != "" {
 			updated.RemotePath = task.RemotePath
@@ -145,6 +199,9 @@ func (s *Service) UpdateTask(task *internal.TaskDefinition) error {
 		if task.SlaveRemotePath != "" {
 			updated.SlaveRemotePath = task.SlaveRemotePath
 		}
+		if task.TransferMode != "" {
+			updated.TransferMode = task.TransferMode
+		}
 		if task.SlaveRemotePaths != nil {
 			updated.SlaveRemotePaths = task.SlaveRemotePaths
 		}
@@ -183,6 +240,9 @@ func (s *Service) UpdateTaskState(taskID string, status internal.TaskStatus, pro
 		if existing.ID != taskID {
 			continue
 		}
+		if (status == "" || status == existing.Status) && (progress < 0 || progress == existing.Progress) {
+			return nil
+		}
 		updated := *existing
 		if status != "" {
 			updated.Status = status
@@ -217,13 +277,7 @@ func (s *Service) DeleteTask(taskID string) error {
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
 
 	// 清理运行历史
-	filtered := make([]*internal.TaskRun, 0, len(s.runs))
-	for _, r := range s.runs {
-		if r.TaskID != taskID {
-			filtered = append(filtered, r)
-		}
-	}
-	s.runs = filtered
+	s.removeRunsLocked(func(r *internal.TaskRun) bool { return r.TaskID == taskID })
 
 	return s.saveLocked()
 }
@@ -282,8 +336,11 @@ func (s *Service) UpdateTemplate(tpl *internal.TaskTemplate) error {
 		if tpl.MasterServerID != "" {
 			updated.MasterServerID = tpl.MasterServerID
 		}
+		// 显式从机列表、选择器与拓扑同属目标配置，一并更新以便清空
 		if tpl.SlaveServerIDs != nil {
 			updated.SlaveServerIDs = tpl.SlaveServerIDs
+			updated.SlaveSelector = tpl.SlaveSelector
+			updated.TopologyID = tpl.TopologyID
 		}
 		if tpl.RemotePath != "" {
 			updated.RemotePath = tpl.RemotePath
@@ -291,6 +348,9 @@ func (s *Service) UpdateTemplate(tpl *internal.TaskTemplate) error {
 		if tpl.SlaveRemotePath != "" {
 			updated.SlaveRemotePath = tpl.SlaveRemotePath
 		}
+		if tpl.TransferMode != "" {
+			updated.TransferMode = tpl.TransferMode
+		}
 		if tpl.SlaveRemotePaths != nil {
 			updated.SlaveRemotePaths = tpl.SlaveRemotePaths
 		}
@@ -335,6 +395,11 @@ func (s *Service) DeleteTemplate(templateID string) error {
 
 // CreateRun 创建运行记录
 func (s *Service) CreateRun(taskID, taskName string) (*internal.TaskRun, error) {
+	return s.CreateRetryRun(taskID, taskName, "")
+}
+
+// CreateRetryRun 创建重试运行记录，retryOf 为原失败运行 ID
+func (s *Service) CreateRetryRun(taskID, taskName, retryOf string) (*internal.TaskRun, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -345,25 +410,43 @@ func (s *Service) CreateRun(taskID, taskName string) (*internal.TaskRun, error)
 		Status:    internal.TaskStatusIdle,
 		Progress:  0,
 		StartedAt: nowString(),
-		Logs:      []string{},
+		RetryOf:   retryOf,
 	}
 
 	s.runs = append([]*internal.TaskRun{run}, s.runs...)
+	s.pruneLocked(run.ID)
 	if err := s.saveLocked(); err != nil {
 		return nil, err
 	}
 	return run, nil
 }
 
-// AppendRunLog 追加运行日志并更新状态
+// AppendRunLog 追加一行文本运行日志并更新状态
+// 日志追加写入独立日志存储；摘要仅在状态变化时持久化
 func (s *Service) AppendRunLog(runID string, status internal.TaskStatus, progress int, logLine string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.appendRunLogLocked(runID, status, progress, logLine)
+}
+
+// AppendRunEntry 追加结构化运行日志并更新状态
+func (s *Service) AppendRunEntry(runID string, status internal.TaskStatus, progress int, entry internal.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendRunLogLocked(runID, status, progress, EncodeLogEntry(entry))
+}
 
+func (s *Service) appendRunLogLocked(runID string, status internal.TaskStatus, progress int, logLine string) error {
 	for i, r := range s.runs {
 		if r.ID != runID {
 			continue
 		}
+		if logLine != "" {
+			if err := s.storage.AppendRunLog(runID, logLine); err != nil {
+				return err
+			}
+		}
+
 		updated := *r
 		if status != "" {
 			updated.Status = status
@@ -372,20 +455,290 @@ func (s *Service) AppendRunLog(runID string, status internal.TaskStatus, progres
 			updated.Progress = progress
 		}
 		if logLine != "" {
-			updated.Logs = append(updated.Logs, logLine)
+			updated.LogCount++
 		}
-		if status == internal.TaskStatusSuccess || status == internal.TaskStatusFailed {
+		if status.IsTerminal() {
 			if updated.FinishedAt == "" {
 				updated.FinishedAt = nowString()
 			}
 		}
 
 		s.runs[i] = &updated
+		if updated.Status == r.Status && updated.FinishedAt == r.FinishedAt {
+			return nil
+		}
 		return s.saveLocked()
 	}
 	return ErrRunNotFound
 }
 
+// GetRunLogs 读取运行日志的展示文本
+func (s *Service) GetRunLogs(runID string) ([]string, error) {
+	if _, err := s.GetRun(runID); err != nil {
+		return nil, err
+	}
+	lines, err := s.storage.LoadRunLogs(runID)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		lines[i] = displayLine(line)
+	}
+	return lines, nil
+}
+
+// GetRunEntries 读取结构化运行日志，旧版本文本日志按前缀转换
+func (s *Service) GetRunEntries(runID string) ([]internal.LogEntry, error) {
+	if _, err := s.GetRun(runID); err != nil {
+		return nil, err
+	}
+	lines, err := s.storage.LoadRunLogs(runID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]internal.LogEntry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, ParseLogLine(line))
+	}
+	return entries, nil
+}
+
+// SetRetention 设置运行历史保留策略并立即清理超出部分
+func (s *Service) SetRetention(retention internal.RunRetention) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retention = retention
+	if s.pruneLocked("") == 0 {
+		return nil
+	}
+	return s.saveLocked()
+}
+
+// pruneLocked 按保留策略清理运行历史，keepID 对应的运行与未结束的运行不会被清理
+// 返回清理的运行数
+func (s *Service) pruneLocked(keepID string) int {
+	maxRuns := s.retention.MaxRunsPerTask
+	var cutoff time.Time
+	if s.retention.MaxAgeDays > 0 {
+		cutoff = time.Now().AddDate(0, 0, -s.retention.MaxAgeDays)
+	}
+	if maxRuns <= 0 && cutoff.IsZero() {
+		return 0
+	}
+
+	// runs 按时间倒序排列，靠前的为最新运行
+	counts := make(map[string]int)
+	return s.removeRunsLocked(func(r *internal.TaskRun) bool {
+		if r.ID == keepID || !r.Status.IsTerminal() {
+			counts[r.TaskID]++
+			return false
+		}
+		if !cutoff.IsZero() {
+			if started, ok := parseTime(r.StartedAt); ok && started.Before(cutoff) {
+				return true
+			}
+		}
+		counts[r.TaskID]++
+		return maxRuns > 0 && counts[r.TaskID] > maxRuns
+	})
+}
+
+// removeRunsLocked 删除满足条件的运行记录及其日志，返回删除数量
+func (s *Service) removeRunsLocked(match func(r *internal.TaskRun) bool) int {
+	filtered := make([]*internal.TaskRun, 0, len(s.runs))
+	removed := 0
+	for _, r := range s.runs {
+		if match(r) {
+			_ = s.storage.DeleteRunLogs(r.ID)
+			removed++
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	s.runs = filtered
+	return removed
+}
+
+// GetRun 获取运行记录
+func (s *Service) GetRun(runID string) (*internal.TaskRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.runs {
+		if r.ID == runID {
+			return r, nil
+		}
+	}
+	return nil, ErrRunNotFound
+}
+
+// SetRunCheckpoint 更新运行断点信息
+func (s *Service) SetRunCheckpoint(runID string, checkpoint *internal.RunCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		updated := *r
+		if checkpoint != nil {
+			cp := *checkpoint
+			cp.CompletedPhases = append([]internal.RunPhase{}, checkpoint.CompletedPhases...)
+			updated.Checkpoint = &cp
+		} else {
+			updated.Checkpoint = nil
+		}
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// SetRunFailure 记录运行失败所在阶段与原因，key 为原因的消息 key
+func (s *Service) SetRunFailure(runID string, phase internal.RunPhase, key, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		updated := *r
+		updated.FailedPhase = phase
+		updated.Error = message
+		updated.ErrorKey = key
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// SetRunTargets 记录运行的目标节点，用于历史检索
+func (s *Service) SetRunTargets(runID string, nodeIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		updated := *r
+		updated.NodeIDs = append([]string{}, nodeIDs...)
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// SetRunRevision 记录运行部署的 SVN 修订号
+func (s *Service) SetRunRevision(runID, revision string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		if r.SVNRevision == revision {
+			return nil
+		}
+		updated := *r
+		updated.SVNRevision = revision
+		// 快照创建时修订号可能尚未解析（HEAD），导出后补全
+		if r.Snapshot != nil && r.Snapshot.Revision == "" {
+			snapshot := *r.Snapshot
+			snapshot.Revision = revision
+			updated.Snapshot = &snapshot
+		}
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// SetRunSnapshot 保存运行配置快照，每个运行只能保存一次
+func (s *Service) SetRunSnapshot(runID string, snapshot *internal.RunSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		if r.Snapshot != nil {
+			return ErrSnapshotExists
+		}
+		updated := *r
+		updated.Snapshot = snapshot
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// StepOutputField 步骤完整输出日志的附加字段，值为步骤在时间线中的序号
+const StepOutputField = "step"
+
+// stepExcerptLimit 步骤记录中保留的输出尾部字节数
+const stepExcerptLimit = 1024
+
+// AddRunStep 追加运行步骤记录
+// step.Output 为完整输出：超出摘要长度时完整内容写入运行日志，步骤记录仅保留尾部片段
+func (s *Service) AddRunStep(runID string, step internal.RunStep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.runs {
+		if r.ID != runID {
+			continue
+		}
+		if len(step.Output) > stepExcerptLimit {
+			entry := internal.LogEntry{
+				Time:    step.FinishedAt,
+				Level:   internal.LogLevelInfo,
+				Phase:   step.Phase,
+				NodeID:  step.NodeID,
+				Message: step.Output,
+				Fields:  map[string]string{StepOutputField: strconv.Itoa(len(r.Steps))},
+			}
+			if err := s.appendRunLogLocked(runID, "", -1, EncodeLogEntry(entry)); err != nil {
+				return err
+			}
+			step.Output = excerpt(step.Output)
+			r = s.runs[i]
+		}
+		updated := *r
+		updated.Steps = append(append([]internal.RunStep{}, r.Steps...), step)
+		s.runs[i] = &updated
+		return s.saveLocked()
+	}
+	return ErrRunNotFound
+}
+
+// excerpt 截取输出尾部，错误信息通常出现在末尾
+func excerpt(output string) string {
+	cut := len(output) - stepExcerptLimit
+	for cut < len(output) && !utf8.RuneStart(output[cut]) {
+		cut++
+	}
+	return "…" + output[cut:]
+}
+
+// StepOutputs 从运行日志中提取步骤完整输出，键为步骤序号；其余日志原样返回
+func StepOutputs(entries []internal.LogEntry) (map[int]string, []internal.LogEntry) {
+	outputs := make(map[int]string)
+	rest := make([]internal.LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if idx, err := strconv.Atoi(entry.Fields[StepOutputField]); err == nil {
+			outputs[idx] = entry.Message
+			continue
+		}
+		rest = append(rest, entry)
+	}
+	return outputs, rest
+}
+
 // ListRuns 返回所有运行记录
 func (s *Service) ListRuns() []*internal.TaskRun {
 	s.mu.RLock()
@@ -424,6 +777,9 @@ func (s *Service) DeleteRun(runID string) error {
 	}
 
 	s.runs = append(s.runs[:idx], s.runs[idx+1:]...)
+	if err := s.storage.DeleteRunLogs(runID); err != nil {
+		return err
+	}
 	return s.saveLocked()
 }
 
@@ -432,12 +788,6 @@ func (s *Service) DeleteRunsByTask(taskID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	filtered := make([]*internal.TaskRun, 0, len(s.runs))
-	for _, r := range s.runs {
-		if r.TaskID != taskID {
-			filtered = append(filtered, r)
-		}
-	}
-	s.runs = filtered
+	s.removeRunsLocked(func(r *internal.TaskRun) bool { return r.TaskID == taskID })
 	return s.saveLocked()
 }
@@ -0,0 +1,208 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunLogsAndRetention(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	storage, err := NewJSONStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	t.Run("AppendRunLog", func(t *testing.T) {
+		run, err := service.CreateRun("task-1", "部署")
+		if err != nil {
+			t.Fatalf("Failed to create run: %v", err)
+		}
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusDownloading, 5, "第一行")
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusDownloading, 10, "多行\n输出")
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusSuccess, 100, "完成")
+
+		logs, err := service.GetRunLogs(run.ID)
+		if err != nil {
+			t.Fatalf("Failed to get run logs: %v", err)
+		}
+		if len(logs) != 3 || logs[1] != "多行\n输出" {
+			t.Errorf("Unexpected logs: %q", logs)
+		}
+
+		// tasks.json 只保存摘要，不包含日志内容
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "tasks.json"))
+		if strings.Contains(string(data), "第一行") {
+			t.Error("tasks.json should not contain run logs")
+		}
+
+		got, _ := service.GetRun(run.ID)
+		if got.LogCount != 3 || got.FinishedAt == "" {
+			t.Errorf("Unexpected run summary: %+v", got)
+		}
+	})
+
+	t.Run("MaxRunsPerTask", func(t *testing.T) {
+		if err := service.SetRetention(internal.RunRetention{MaxRunsPerTask: 2}); err != nil {
+			t.Fatalf("Failed to set retention: %v", err)
+		}
+		var ids []string
+		for i := 0; i < 4; i++ {
+			run, _ := service.CreateRun("task-2", "")
+			_ = service.AppendRunLog(run.ID, internal.TaskStatusSuccess, 100, "ok")
+			ids = append(ids, run.ID)
+		}
+
+		runs := service.ListRunsByTask("task-2")
+		if len(runs) != 2 || runs[0].ID != ids[3] || runs[1].ID != ids[2] {
+			t.Errorf("Expected the 2 newest runs to be kept, got %d", len(runs))
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "runs", ids[0]+".log")); !os.IsNotExist(err) {
+			t.Error("Log file of pruned run should be removed")
+		}
+	})
+
+	t.Run("KeepsUnfinishedRuns", func(t *testing.T) {
+		if err := service.SetRetention(internal.RunRetention{MaxRunsPerTask: 1}); err != nil {
+			t.Fatalf("Failed to set retention: %v", err)
+		}
+		running, _ := service.CreateRun("task-4", "")
+		_ = service.AppendRunLog(running.ID, internal.TaskStatusSyncing, 60, "")
+		next, _ := service.CreateRun("task-4", "")
+
+		if _, err := service.GetRun(running.ID); err != nil {
+			t.Errorf("Expected in-progress run to survive pruning, got %v", err)
+		}
+		if _, err := service.GetRun(next.ID); err != nil {
+			t.Errorf("Expected new run to be kept, got %v", err)
+		}
+	})
+
+	t.Run("MaxAgeDays", func(t *testing.T) {
+		service.mu.Lock()
+		old := &internal.TaskRun{
+			ID:        "run-old",
+			TaskID:    "task-3",
+			Status:    internal.TaskStatusSuccess,
+			StartedAt: time.Now().AddDate(0, 0, -40).Format(timeLayout),
+		}
+		service.runs = append(service.runs, old)
+		service.mu.Unlock()
+
+		if err := service.SetRetention(internal.RunRetention{MaxAgeDays: 30}); err != nil {
+			t.Fatalf("Failed to set retention: %v", err)
+		}
+		if _, err := service.GetRun("run-old"); err != ErrRunNotFound {
+			t.Errorf("Expected old run to be pruned, got %v", err)
+		}
+	})
+}
+
+func TestMigrateInlineLogs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// 旧版本 tasks.json：日志内联在运行记录中
+	legacy := internal.TaskStore{
+		Runs: []*internal.TaskRun{{
+			ID:     "run-legacy",
+			TaskID: "task-1",
+			Status: internal.TaskStatusFailed,
+			Logs:   []string{"line 1", "line 2"},
+		}},
+	}
+	data, _ := json.Marshal(legacy)
+	if err := os.WriteFile(filepath.Join(tmpDir, "tasks.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write legacy store: %v", err)
+	}
+
+	storage, _ := NewJSONStorage(tmpDir)
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	logs, err := service.GetRunLogs("run-legacy")
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("Expected migrated logs, got %q (%v)", logs, err)
+	}
+	run, _ := service.GetRun("run-legacy")
+	if len(run.Logs) != 0 || run.LogCount != 2 {
+		t.Errorf("Expected inline logs to be stripped, got %+v", run)
+	}
+
+	data, _ = os.ReadFile(filepath.Join(tmpDir, "tasks.json"))
+	if strings.Contains(string(data), "line 1") {
+		t.Error("tasks.json should not contain migrated logs")
+	}
+}
+
+func TestRunSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, _ := NewJSONStorage(tmpDir)
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	run, _ := service.CreateRun("task-1", "部署")
+	snapshot := &internal.RunSnapshot{
+		Request:     internal.TaskRunRequest{TaskID: "task-1", Commands: []string{"restart"}},
+		ResourceURL: "svn://repo/app",
+	}
+	if err := service.SetRunSnapshot(run.ID, snapshot); err != nil {
+		t.Fatalf("Failed to set snapshot: %v", err)
+	}
+	if err := service.SetRunSnapshot(run.ID, &internal.RunSnapshot{}); err != ErrSnapshotExists {
+		t.Errorf("Expected ErrSnapshotExists, got %v", err)
+	}
+	_ = service.SetRunRevision(run.ID, "4821")
+
+	reloaded, _ := NewService(storage)
+	got, _ := reloaded.GetRun(run.ID)
+	if got.Snapshot == nil || got.Snapshot.ResourceURL != "svn://repo/app" || got.Snapshot.Revision != "4821" {
+		t.Errorf("Unexpected snapshot after reload: %+v", got.Snapshot)
+	}
+	if len(got.Snapshot.Request.Commands) != 1 {
+		t.Errorf("Unexpected snapshot request: %+v", got.Snapshot.Request)
+	}
+}
+
+func TestRunStepOutput(t *testing.T) {
+	storage, _ := NewJSONStorage(t.TempDir())
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	run, _ := service.CreateRun("task-1", "部署")
+	full := strings.Repeat("日志行\n", stepExcerptLimit)
+	_ = service.AddRunStep(run.ID, internal.RunStep{Name: "导出", Output: "ok"})
+	_ = service.AddRunStep(run.ID, internal.RunStep{Name: "执行", NodeID: "n1", Output: full})
+
+	got, _ := service.GetRun(run.ID)
+	if got.Steps[0].Output != "ok" {
+		t.Errorf("Expected short output kept, got %q", got.Steps[0].Output)
+	}
+	short := got.Steps[1].Output
+	if len(short) > stepExcerptLimit+len("…") || !strings.HasSuffix(full, strings.TrimPrefix(short, "…")) {
+		t.Errorf("Expected output tail excerpt, got %d bytes", len(short))
+	}
+
+	entries, err := service.GetRunEntries(run.ID)
+	if err != nil {
+		t.Fatalf("Failed to get entries: %v", err)
+	}
+	outputs, rest := StepOutputs(entries)
+	if outputs[1] != full || len(outputs) != 1 || len(rest) != 0 {
+		t.Errorf("Expected full output for step 1, got %d outputs and %d other entries", len(outputs), len(rest))
+	}
+}
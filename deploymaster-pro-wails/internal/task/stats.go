@@ -0,0 +1,191 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"math"
+	"sort"
+	"time"
+)
+
+// statsTopN 失败排行保留条数
+const statsTopN = 10
+
+// Stats 统计 since 之后开始的运行，since 为零值时统计全部
+// 节点仅给出 ID，展示名称由调用方补全
+func (s *Service) Stats(since time.Time) *internal.DeploymentStats {
+	s.mu.RLock()
+	runs := append([]*internal.TaskRun{}, s.runs...)
+	s.mu.RUnlock()
+
+	stats := &internal.DeploymentStats{
+		RunsPerDay:     []internal.DailyRunStats{},
+		Tasks:          []internal.TaskRunStats{},
+		PhaseDurations: []internal.PhaseDurationStats{},
+	}
+	if !since.IsZero() {
+		stats.From = since.Format("2006-01-02")
+	}
+
+	days := make(map[string]*internal.DailyRunStats)
+	tasks := make(map[string]*internal.TaskRunStats)
+	durations := make(map[internal.RunPhase][]int64)
+	nodeFailures := make(map[string]int)
+	phaseFailures := make(map[string]int)
+	errors := make(map[string]int)
+	errorLabels := make(map[string]string)
+
+	for _, r := range runs {
+		started, ok := parseTime(r.StartedAt)
+		if !ok || started.Before(since) {
+			continue
+		}
+
+		date := started.Format("2006-01-02")
+		day := days[date]
+		if day == nil {
+			day = &internal.DailyRunStats{Date: date}
+			days[date] = day
+		}
+		ts := tasks[r.TaskID]
+		if ts == nil {
+			ts = &internal.TaskRunStats{TaskID: r.TaskID}
+			tasks[r.TaskID] = ts
+		}
+		// 运行按时间倒序，首次出现即最新名称
+		if ts.TaskName == "" {
+			ts.TaskName = r.TaskName
+		}
+
+		stats.Total++
+		day.Total++
+		ts.Total++
+		switch r.Status {
+		case internal.TaskStatusSuccess:
+			stats.Success++
+			day.Success++
+			ts.Success++
+		case internal.TaskStatusFailed:
+			stats.Failed++
+			day.Failed++
+			ts.Failed++
+			if r.FailedPhase != "" {
+				phaseFailures[string(r.FailedPhase)]++
+			}
+			if key := errorGroup(r); key != "" {
+				errors[key]++
+				// 同组错误参数各异，展示最新一次的完整原因
+				if _, ok := errorLabels[key]; !ok {
+					errorLabels[key] = r.Error
+				}
+			}
+		}
+
+		phaseTotals := make(map[internal.RunPhase]int64)
+		for _, step := range r.Steps {
+			switch step.Status {
+			case internal.RunStepSuccess:
+				phaseTotals[step.Phase] += step.DurationMs
+			case internal.RunStepFailed:
+				if step.NodeID != "" {
+					nodeFailures[step.NodeID]++
+				}
+			}
+		}
+		for phase, ms := range phaseTotals {
+			durations[phase] = append(durations[phase], ms)
+		}
+	}
+
+	// 指定起始日期时补齐无运行的日期，便于绘制趋势
+	if !since.IsZero() {
+		today := time.Now().Format("2006-01-02")
+		for d := since; d.Format("2006-01-02") <= today; d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			if days[date] == nil {
+				days[date] = &internal.DailyRunStats{Date: date}
+			}
+		}
+	}
+	for _, day := range days {
+		stats.RunsPerDay = append(stats.RunsPerDay, *day)
+	}
+	sort.Slice(stats.RunsPerDay, func(i, j int) bool { return stats.RunsPerDay[i].Date < stats.RunsPerDay[j].Date })
+
+	for _, ts := range tasks {
+		if finished := ts.Success + ts.Failed; finished > 0 {
+			ts.SuccessRate = float64(ts.Success) / float64(finished)
+		}
+		stats.Tasks = append(stats.Tasks, *ts)
+	}
+	sort.Slice(stats.Tasks, func(i, j int) bool {
+		if stats.Tasks[i].Total != stats.Tasks[j].Total {
+			return stats.Tasks[i].Total > stats.Tasks[j].Total
+		}
+		return stats.Tasks[i].TaskName < stats.Tasks[j].TaskName
+	})
+
+	for _, phase := range []internal.RunPhase{internal.RunPhaseExport, internal.RunPhaseUpload, internal.RunPhaseSync, internal.RunPhaseExecute} {
+		values := durations[phase]
+		if len(values) == 0 {
+			continue
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		var sum int64
+		for _, v := range values {
+			sum += v
+		}
+		stats.PhaseDurations = append(stats.PhaseDurations, internal.PhaseDurationStats{
+			Phase:  phase,
+			Count:  len(values),
+			MeanMs: sum / int64(len(values)),
+			P95Ms:  percentile(values, 0.95),
+		})
+	}
+
+	stats.FailingNodes = topCounts(nodeFailures)
+	stats.FailingPhases = topCounts(phaseFailures)
+	stats.TopErrors = topCounts(errors)
+	for i := range stats.TopErrors {
+		stats.TopErrors[i].Label = errorLabels[stats.TopErrors[i].Key]
+	}
+	return stats
+}
+
+// errorGroup 返回失败原因的归类 key：按失败阶段与消息 key 归类，忽略路径、节点等参数
+// 旧运行未记录消息 key 时退回完整原因
+func errorGroup(r *internal.TaskRun) string {
+	if r.ErrorKey == "" {
+		return r.Error
+	}
+	return string(r.FailedPhase) + "/" + r.ErrorKey
+}
+
+// percentile 最近秩法计算分位数，values 需已升序排列
+func percentile(values []int64, p float64) int64 {
+	rank := int(math.Ceil(p*float64(len(values)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(values) {
+		rank = len(values) - 1
+	}
+	return values[rank]
+}
+
+// topCounts 按次数倒序返回前 statsTopN 项
+func topCounts(counts map[string]int) []internal.FailureCount {
+	result := make([]internal.FailureCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, internal.FailureCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	if len(result) > statsTopN {
+		result = result[:statsTopN]
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"deploymaster-pro-wails/internal"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	storage, err := NewJSONStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		run, _ := service.CreateRun("task-web", "部署 Web")
+		_ = service.AddRunStep(run.ID, internal.RunStep{Phase: internal.RunPhaseExport, Status: internal.RunStepSuccess, DurationMs: int64(1000 * (i + 1))})
+		_ = service.AppendRunLog(run.ID, internal.TaskStatusSuccess, 100, "done")
+	}
+	failed, _ := service.CreateRun("task-web", "部署 Web")
+	_ = service.AddRunStep(failed.ID, internal.RunStep{Phase: internal.RunPhaseExport, Status: internal.RunStepSkipped, DurationMs: 1})
+	_ = service.AddRunStep(failed.ID, internal.RunStep{Phase: internal.RunPhaseExecute, NodeID: "web-3", Status: internal.RunStepFailed})
+	_ = service.SetRunFailure(failed.ID, internal.RunPhaseExecute, "pipeline.execFailed", "web-3: 远程脚本执行失败")
+	_ = service.AppendRunLog(failed.ID, internal.TaskStatusFailed, 85, "failed")
+	// 同一原因在不同节点失败，应归为一类
+	again, _ := service.CreateRun("task-web", "部署 Web")
+	_ = service.SetRunFailure(again.ID, internal.RunPhaseExecute, "pipeline.execFailed", "web-4: 远程脚本执行失败")
+	_ = service.AppendRunLog(again.ID, internal.TaskStatusFailed, 85, "failed")
+	_, _ = service.CreateRun("task-api", "部署 API")
+
+	t.Run("Summary", func(t *testing.T) {
+		stats := service.Stats(time.Time{})
+		if stats.Total != 6 || stats.Success != 3 || stats.Failed != 2 {
+			t.Errorf("Unexpected totals: %+v", stats)
+		}
+		if len(stats.Tasks) != 2 || stats.Tasks[0].TaskID != "task-web" || stats.Tasks[0].SuccessRate != 0.6 {
+			t.Errorf("Unexpected task stats: %+v", stats.Tasks)
+		}
+		if len(stats.RunsPerDay) != 1 || stats.RunsPerDay[0].Total != 6 {
+			t.Errorf("Unexpected daily stats: %+v", stats.RunsPerDay)
+		}
+	})
+
+	t.Run("PhaseDurations", func(t *testing.T) {
+		stats := service.Stats(time.Time{})
+		if len(stats.PhaseDurations) != 1 {
+			t.Fatalf("Expected only export durations, got %+v", stats.PhaseDurations)
+		}
+		d := stats.PhaseDurations[0]
+		if d.Count != 3 || d.MeanMs != 2000 || d.P95Ms != 3000 {
+			t.Errorf("Unexpected export durations: %+v", d)
+		}
+	})
+
+	t.Run("Failures", func(t *testing.T) {
+		stats := service.Stats(time.Time{})
+		if len(stats.FailingNodes) != 1 || stats.FailingNodes[0].Key != "web-3" {
+			t.Errorf("Unexpected failing nodes: %+v", stats.FailingNodes)
+		}
+		if len(stats.FailingPhases) != 1 || stats.FailingPhases[0].Key != string(internal.RunPhaseExecute) || stats.FailingPhases[0].Count != 2 {
+			t.Errorf("Unexpected failing phases: %+v", stats.FailingPhases)
+		}
+		if len(stats.TopErrors) != 1 || stats.TopErrors[0].Key != "execute/pipeline.execFailed" || stats.TopErrors[0].Count != 2 {
+			t.Errorf("Expected errors grouped by phase and key, got %+v", stats.TopErrors)
+		} else if stats.TopErrors[0].Label != "web-4: 远程脚本执行失败" {
+			t.Errorf("Expected the latest message as label, got %q", stats.TopErrors[0].Label)
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		since := time.Now().AddDate(0, 0, -6)
+		stats := service.Stats(since)
+		if len(stats.RunsPerDay) != 7 {
+			t.Errorf("Expected 7 days including empty ones, got %d", len(stats.RunsPerDay))
+		}
+		if stats := service.Stats(time.Now().Add(time.Hour)); stats.Total != 0 {
+			t.Errorf("Expected no runs in the future, got %d", stats.Total)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"bufio"
 	"deploymaster-pro-wails/internal"
 	"encoding/json"
 	"os"
@@ -11,20 +12,26 @@ import (
 
 // Storage 定义任务存储接口
 // 包含任务、模板和执行历史
-// 注意：仅存储配置与历史摘要，敏感凭据不存储
+// 注意：仅存储配置与历史摘要，敏感凭据不存储；
+// 运行日志按运行单独追加存储，不随摘要整体重写
 //
 //go:generate echo "no codegen"
 type Storage interface {
 	Load() (*internal.TaskStore, error)
 	Save(store *internal.TaskStore) error
+	AppendRunLog(runID, line string) error
+	LoadRunLogs(runID string) ([]string, error)
+	DeleteRunLogs(runID string) error
 }
 
 // JSONStorage 基于JSON文件的存储实现
-// 存储文件名：tasks.json
+// 存储文件名：tasks.json，运行日志：runs/<runID>.log
 // 文件放置位置与节点/资源数据一致
 type JSONStorage struct {
 	filePath string
+	logDir   string
 	mu       sync.RWMutex
+	logMu    sync.Mutex
 }
 
 // NewJSONStorage 创建任务存储实例
@@ -34,7 +41,10 @@ func NewJSONStorage(dataDir string) (*JSONStorage, error) {
 	}
 
 	filePath := filepath.Join(dataDir, "tasks.json")
-	return &JSONStorage{filePath: filePath}, nil
+	return &JSONStorage{
+		filePath: filePath,
+		logDir:   filepath.Join(dataDir, "runs"),
+	}, nil
 }
 
 // Load 从文件加载任务数据
@@ -93,3 +103,78 @@ func (s *JSONStorage) Save(store *internal.TaskStore) error {
 
 	return os.Rename(tmpFile, s.filePath)
 }
+
+// AppendRunLog 追加一行运行日志
+// 每行以 JSON 字符串编码，日志内容中的换行不会破坏文件结构
+func (s *JSONStorage) AppendRunLog(runID, line string) error {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	if err := os.MkdirAll(s.logDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.runLogPath(runID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadRunLogs 读取运行日志，日志文件不存在时返回空列表
+func (s *JSONStorage) LoadRunLogs(runID string) ([]string, error) {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	f, err := os.Open(s.runLogPath(runID))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	logs := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		raw := scanner.Bytes()
+		if len(raw) == 0 {
+			continue
+		}
+		var line string
+		if err := json.Unmarshal(raw, &line); err != nil {
+			// 兼容非 JSON 编码的行
+			line = string(raw)
+		}
+		logs = append(logs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// DeleteRunLogs 删除运行日志文件
+func (s *JSONStorage) DeleteRunLogs(runID string) error {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	if err := os.Remove(s.runLogPath(runID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *JSONStorage) runLogPath(runID string) string {
+	return filepath.Join(s.logDir, filepath.Base(runID)+".log")
+}
@@ -0,0 +1,146 @@
+package telemetry
+
+import (
+	"deploymaster-pro-wails/internal"
+	"sync"
+	"time"
+)
+
+// collectConcurrency 每轮同时采集的节点数
+const collectConcurrency = 4
+
+// CollectFunc 采集单个节点的资源数据
+type CollectFunc func(node *internal.Node) (*internal.NodeMetrics, error)
+
+// ErrorTextFunc 将采集错误渲染为展示文本
+type ErrorTextFunc func(err error) string
+
+// Collector 节点资源采集：按固定间隔采集指定节点，并缓存每个节点最近一次的结果
+type Collector struct {
+	nodes     func() []*internal.Node // 定期采集的节点
+	collect   CollectFunc
+	errorText ErrorTextFunc
+	onMetrics func(metrics *internal.NodeMetrics) // 每次采集完成后回调，可为 nil
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*internal.NodeMetrics
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewCollector 创建资源采集器，nodes 返回需要定期采集的节点
+// errorText 为 nil 时直接使用 err.Error()
+func NewCollector(nodes func() []*internal.Node, collect CollectFunc, errorText ErrorTextFunc, onMetrics func(metrics *internal.NodeMetrics)) *Collector {
+	if errorText == nil {
+		errorText = func(err error) string { return err.Error() }
+	}
+	return &Collector{
+		nodes:     nodes,
+		collect:   collect,
+		errorText: errorText,
+		onMetrics: onMetrics,
+		now:       time.Now,
+		cache:     make(map[string]*internal.NodeMetrics),
+	}
+}
+
+// Start 以 interval 为间隔启动定期采集，启动后立即采集一轮；已在运行时按新间隔重启，interval <= 0 时仅停止
+func (c *Collector) Start(interval time.Duration) {
+	c.Stop()
+	if interval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	stop, done := make(chan struct{}), make(chan struct{})
+	c.stop, c.done = stop, done
+	c.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			c.CollectAll()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止定期采集并等待进行中的一轮结束
+func (c *Collector) Stop() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// CollectAll 立即采集所有定期采集的节点
+func (c *Collector) CollectAll() []*internal.NodeMetrics {
+	nodes := c.nodes()
+	results := make([]*internal.NodeMetrics, len(nodes))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, collectConcurrency)
+	for i, node := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, node *internal.Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = c.Collect(node)
+		}(i, node)
+	}
+	wg.Wait()
+	return results
+}
+
+// Collect 立即采集单个节点并更新缓存；失败时返回仅带 Error 的结果
+func (c *Collector) Collect(node *internal.Node) *internal.NodeMetrics {
+	metrics, err := c.collect(node)
+	if err != nil {
+		metrics = &internal.NodeMetrics{Error: c.errorText(err)}
+	}
+	metrics.NodeID = node.ID
+	metrics.CollectedAt = c.now().UnixMilli()
+
+	c.mu.Lock()
+	c.cache[node.ID] = metrics
+	c.mu.Unlock()
+	if c.onMetrics != nil {
+		c.onMetrics(metrics)
+	}
+	return metrics
+}
+
+// Get 返回节点的缓存结果；没有缓存或已超过 maxAge 时重新采集
+func (c *Collector) Get(node *internal.Node, maxAge time.Duration) *internal.NodeMetrics {
+	if metrics, ok := c.Cached(node.ID); ok && c.now().Sub(time.UnixMilli(metrics.CollectedAt)) < maxAge {
+		return metrics
+	}
+	return c.Collect(node)
+}
+
+// Cached 返回节点最近一次的采集结果
+func (c *Collector) Cached(nodeID string) (*internal.NodeMetrics, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metrics, ok := c.cache[nodeID]
+	return metrics, ok
+}
+
+// Forget 清除节点的缓存结果
+func (c *Collector) Forget(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cache, nodeID)
+}
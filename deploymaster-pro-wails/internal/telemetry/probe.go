@@ -0,0 +1,191 @@
+package telemetry
+
+import (
+	"bufio"
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+)
+
+var (
+	// ErrUnsupportedOS 远程节点不是 Linux，无法读取 /proc
+	ErrUnsupportedOS = errors.New("telemetry requires a linux node")
+	// ErrMalformedOutput 采集脚本输出缺少必要字段
+	ErrMalformedOutput = errors.New("malformed telemetry output")
+)
+
+// 采集脚本输出以 "@@<段名>" 行分段
+const sectionPrefix = "@@"
+
+// Command 返回一次性采集负载、内存、运行时长、CPU 数及各路径磁盘空间的远程命令
+func Command(diskPaths []string) string {
+	parts := []string{
+		"echo @@os; uname -s",
+		"echo @@loadavg; cat /proc/loadavg 2>/dev/null",
+		"echo @@meminfo; cat /proc/meminfo 2>/dev/null",
+		"echo @@uptime; cat /proc/uptime 2>/dev/null",
+		"echo @@cpus; grep -c ^processor /proc/cpuinfo 2>/dev/null",
+	}
+	for _, p := range diskPaths {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		quoted := shellescape.Quote(p)
+		parts = append(parts, fmt.Sprintf("echo @@disk %s; df -kP %s 2>/dev/null | tail -n +2", quoted, quoted))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// section 脚本输出中的一段
+type section struct {
+	name  string
+	arg   string
+	lines []string
+}
+
+// Parse 解析 Command 的输出
+func Parse(output string) (*internal.NodeMetrics, error) {
+	var sections []*section
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if name, ok := strings.CutPrefix(line, sectionPrefix); ok {
+			name, arg, _ := strings.Cut(name, " ")
+			sections = append(sections, &section{name: name, arg: arg})
+			continue
+		}
+		if len(sections) > 0 && strings.TrimSpace(line) != "" {
+			current := sections[len(sections)-1]
+			current.lines = append(current.lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	m := &internal.NodeMetrics{Disks: []internal.DiskUsage{}}
+	seen := make(map[string]bool)
+	for _, s := range sections {
+		seen[s.name] = true
+		switch s.name {
+		case "os":
+			if len(s.lines) == 0 || !strings.EqualFold(strings.TrimSpace(s.lines[0]), "linux") {
+				return nil, ErrUnsupportedOS
+			}
+		case "loadavg":
+			if err := parseLoadAvg(m, s.lines); err != nil {
+				return nil, err
+			}
+		case "meminfo":
+			if err := parseMemInfo(m, s.lines); err != nil {
+				return nil, err
+			}
+		case "uptime":
+			if len(s.lines) == 0 {
+				return nil, ErrMalformedOutput
+			}
+			fields := strings.Fields(s.lines[0])
+			seconds, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: uptime %q", ErrMalformedOutput, s.lines[0])
+			}
+			m.UptimeSeconds = int64(seconds)
+		case "cpus":
+			if len(s.lines) > 0 {
+				m.CPUCores, _ = strconv.Atoi(strings.TrimSpace(s.lines[0]))
+			}
+		case "disk":
+			if disk, ok := parseDF(s.arg, s.lines); ok {
+				m.Disks = append(m.Disks, disk)
+			}
+		}
+	}
+	if !seen["os"] || !seen["loadavg"] || !seen["meminfo"] {
+		return nil, ErrMalformedOutput
+	}
+	return m, nil
+}
+
+// parseLoadAvg 解析 /proc/loadavg，如 "0.52 0.58 0.59 1/123 4567"
+func parseLoadAvg(m *internal.NodeMetrics, lines []string) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: empty loadavg", ErrMalformedOutput)
+	}
+	fields := strings.Fields(lines[0])
+	if len(fields) < 3 {
+		return fmt.Errorf("%w: loadavg %q", ErrMalformedOutput, lines[0])
+	}
+	loads := make([]float64, 3)
+	for i := range loads {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return fmt.Errorf("%w: loadavg %q", ErrMalformedOutput, lines[0])
+		}
+		loads[i] = v
+	}
+	m.Load1, m.Load5, m.Load15 = loads[0], loads[1], loads[2]
+	return nil
+}
+
+// parseMemInfo 解析 /proc/meminfo；缺少 MemAvailable 的旧内核以 MemFree+Buffers+Cached 估算
+func parseMemInfo(m *internal.NodeMetrics, lines []string) error {
+	values := make(map[string]int64)
+	for _, line := range lines {
+		key, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[key] = kb * 1024
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok || total == 0 {
+		return fmt.Errorf("%w: missing MemTotal", ErrMalformedOutput)
+	}
+	available, ok := values["MemAvailable"]
+	if !ok {
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+	m.MemTotal, m.MemAvailable = total, available
+	m.MemUsedPercent = float64(total-available) * 100 / float64(total)
+	return nil
+}
+
+// parseDF 解析 df -kP 的数据行：Filesystem 1024-blocks Used Available Capacity Mounted-on
+func parseDF(path string, lines []string) (internal.DiskUsage, bool) {
+	if len(lines) == 0 {
+		return internal.DiskUsage{}, false
+	}
+	fields := strings.Fields(lines[0])
+	if len(fields) < 6 {
+		return internal.DiskUsage{}, false
+	}
+	total, err1 := strconv.ParseInt(fields[1], 10, 64)
+	free, err2 := strconv.ParseInt(fields[3], 10, 64)
+	if err1 != nil || err2 != nil {
+		return internal.DiskUsage{}, false
+	}
+
+	disk := internal.DiskUsage{
+		Path:  path,
+		Mount: strings.Join(fields[5:], " "),
+		Total: total * 1024,
+		Free:  free * 1024,
+	}
+	if total > 0 {
+		disk.UsedPercent = float64(total-free) * 100 / float64(total)
+	}
+	return disk, true
+}
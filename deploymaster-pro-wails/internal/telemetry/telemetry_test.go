@@ -0,0 +1,155 @@
+package telemetry
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const sampleOutput = `@@os
+Linux
+@@loadavg
+0.52 0.58 0.59 1/123 4567
+@@meminfo
+MemTotal:        8000000 kB
+MemFree:         1000000 kB
+MemAvailable:    6000000 kB
+@@uptime
+86461.37 170000.12
+@@cpus
+4
+@@disk /
+/dev/sda1 41152736 20576368 20576368 50% /
+@@disk /data/my app
+/dev/sdb1 1000 250 750 25% /data
+@@disk /missing
+`
+
+func TestCommand(t *testing.T) {
+	cmd := Command([]string{"/", " ", "/data/my app"})
+	if !strings.Contains(cmd, "df -kP / 2>/dev/null") || !strings.Contains(cmd, "df -kP '/data/my app'") {
+		t.Errorf("Unexpected command %q", cmd)
+	}
+	if strings.Count(cmd, "@@disk") != 2 {
+		t.Errorf("Blank paths should be skipped: %q", cmd)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Linux", func(t *testing.T) {
+		m, err := Parse(sampleOutput)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if m.Load1 != 0.52 || m.Load15 != 0.59 || m.CPUCores != 4 || m.UptimeSeconds != 86461 {
+			t.Errorf("Unexpected load/uptime %+v", m)
+		}
+		if m.MemTotal != 8000000*1024 || m.MemAvailable != 6000000*1024 || m.MemUsedPercent != 25 {
+			t.Errorf("Unexpected memory %+v", m)
+		}
+		if len(m.Disks) != 2 {
+			t.Fatalf("Expected missing path to be skipped, got %+v", m.Disks)
+		}
+		if d := m.Disks[1]; d.Path != "/data/my app" || d.Mount != "/data" || d.Free != 750*1024 || d.UsedPercent != 25 {
+			t.Errorf("Unexpected disk %+v", d)
+		}
+	})
+
+	t.Run("MemAvailableFallback", func(t *testing.T) {
+		out := "@@os\nLinux\n@@loadavg\n0 0 0 1/1 1\n@@meminfo\nMemTotal: 1000 kB\nMemFree: 100 kB\nBuffers: 100 kB\nCached: 300 kB\n"
+		m, err := Parse(out)
+		if err != nil || m.MemAvailable != 500*1024 {
+			t.Errorf("Unexpected fallback %+v (%v)", m, err)
+		}
+	})
+
+	t.Run("UnsupportedOS", func(t *testing.T) {
+		if _, err := Parse("@@os\nDarwin\n@@loadavg\n@@meminfo\n"); !errors.Is(err, ErrUnsupportedOS) {
+			t.Errorf("Expected ErrUnsupportedOS, got %v", err)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		if _, err := Parse("@@os\nLinux\n@@loadavg\nbusy\n@@meminfo\nMemTotal: 1 kB\n"); !errors.Is(err, ErrMalformedOutput) {
+			t.Errorf("Expected ErrMalformedOutput, got %v", err)
+		}
+		if _, err := Parse("permission denied"); !errors.Is(err, ErrMalformedOutput) {
+			t.Errorf("Expected ErrMalformedOutput, got %v", err)
+		}
+	})
+}
+
+func TestCollector(t *testing.T) {
+	nodes := []*internal.Node{{ID: "m1"}, {ID: "m2"}}
+	var calls atomic.Int32
+	collect := func(n *internal.Node) (*internal.NodeMetrics, error) {
+		calls.Add(1)
+		if n.ID == "m2" {
+			return nil, errors.New("connection refused")
+		}
+		return &internal.NodeMetrics{Load1: 1.5}, nil
+	}
+
+	t.Run("CollectAll", func(t *testing.T) {
+		var events atomic.Int32
+		c := NewCollector(func() []*internal.Node { return nodes }, collect, nil, func(*internal.NodeMetrics) { events.Add(1) })
+		results := c.CollectAll()
+		if len(results) != 2 || results[0].NodeID != "m1" || results[0].Load1 != 1.5 {
+			t.Fatalf("Unexpected results %+v", results)
+		}
+		if results[1].Error != "connection refused" || results[1].CollectedAt == 0 {
+			t.Errorf("Expected failure to be recorded, got %+v", results[1])
+		}
+		if events.Load() != 2 {
+			t.Errorf("Expected 2 callbacks, got %d", events.Load())
+		}
+	})
+
+	t.Run("ErrorText", func(t *testing.T) {
+		c := NewCollector(func() []*internal.Node { return nil }, collect, func(err error) string { return "采集失败：" + err.Error() }, nil)
+		if got := c.Collect(nodes[1]).Error; got != "采集失败：connection refused" {
+			t.Errorf("Expected rendered error, got %q", got)
+		}
+	})
+
+	t.Run("GetUsesCache", func(t *testing.T) {
+		calls.Store(0)
+		clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := NewCollector(func() []*internal.Node { return nil }, collect, nil, nil)
+		c.now = func() time.Time { return clock }
+
+		c.Get(nodes[0], time.Minute)
+		clock = clock.Add(30 * time.Second)
+		c.Get(nodes[0], time.Minute)
+		if calls.Load() != 1 {
+			t.Errorf("Expected cached result within maxAge, got %d collections", calls.Load())
+		}
+		clock = clock.Add(time.Minute)
+		c.Get(nodes[0], time.Minute)
+		if calls.Load() != 2 {
+			t.Errorf("Expected refresh after maxAge, got %d collections", calls.Load())
+		}
+
+		c.Forget("m1")
+		if _, ok := c.Cached("m1"); ok {
+			t.Error("Expected cache to be cleared")
+		}
+	})
+
+	t.Run("StartStop", func(t *testing.T) {
+		calls.Store(0)
+		c := NewCollector(func() []*internal.Node { return nodes[:1] }, collect, nil, nil)
+		c.Start(time.Hour)
+		deadline := time.Now().Add(time.Second)
+		for calls.Load() == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		c.Stop()
+		if calls.Load() != 1 {
+			t.Errorf("Expected one immediate collection, got %d", calls.Load())
+		}
+	})
+}
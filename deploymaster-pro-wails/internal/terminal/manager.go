@@ -0,0 +1,165 @@
+package terminal
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrSessionNotFound 终端会话不存在或已结束
+var ErrSessionNotFound = errors.New("terminal session not found")
+
+// readBufferSize 每次读取终端输出的缓冲大小
+const readBufferSize = 32 * 1024
+
+// Shell 远程交互式会话；Read 在会话结束时返回错误，正常结束为 io.EOF
+type Shell interface {
+	io.ReadWriteCloser
+	Resize(cols, rows int) error
+}
+
+// session 已打开的终端会话
+type session struct {
+	info  internal.TerminalSession
+	shell Shell
+	mu    sync.Mutex // 串行化输入写入
+}
+
+// Manager 终端会话管理：为每个会话转发输出，会话结束时回调并自动移除
+type Manager struct {
+	onOutput func(sessionID string, data []byte)
+	onClose  func(sessionID string, err error) // err 为 nil 表示正常结束
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewManager 创建终端会话管理器
+func NewManager(onOutput func(sessionID string, data []byte), onClose func(sessionID string, err error)) *Manager {
+	return &Manager{
+		onOutput: onOutput,
+		onClose:  onClose,
+		sessions: make(map[string]*session),
+	}
+}
+
+// Open 登记已建立的 shell 并开始转发输出
+func (m *Manager) Open(nodeID string, shell Shell) *internal.TerminalSession {
+	s := &session{
+		info: internal.TerminalSession{
+			ID:        uuid.NewString(),
+			NodeID:    nodeID,
+			StartedAt: time.Now().Format(time.RFC3339),
+		},
+		shell: shell,
+	}
+
+	m.mu.Lock()
+	m.sessions[s.info.ID] = s
+	m.mu.Unlock()
+
+	go m.pump(s)
+	info := s.info
+	return &info
+}
+
+// pump 转发输出直到会话结束
+func (m *Manager) pump(s *session) {
+	buf := make([]byte, readBufferSize)
+	var err error
+	for {
+		var n int
+		n, err = s.shell.Read(buf)
+		if n > 0 && m.onOutput != nil {
+			m.onOutput(s.info.ID, append([]byte(nil), buf[:n]...))
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	m.mu.Lock()
+	delete(m.sessions, s.info.ID)
+	m.mu.Unlock()
+	_ = s.shell.Close()
+
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if m.onClose != nil {
+		m.onClose(s.info.ID, err)
+	}
+}
+
+func (m *Manager) get(sessionID string) (*session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// Write 向会话写入输入
+func (m *Manager) Write(sessionID string, data []byte) error {
+	s, err := m.get(sessionID)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.shell.Write(data)
+	return err
+}
+
+// Resize 调整会话窗口大小
+func (m *Manager) Resize(sessionID string, cols, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		return errors.New("invalid terminal size")
+	}
+	s, err := m.get(sessionID)
+	if err != nil {
+		return err
+	}
+	return s.shell.Resize(cols, rows)
+}
+
+// Close 结束会话，结束回调随后由输出转发协程触发
+func (m *Manager) Close(sessionID string) error {
+	s, err := m.get(sessionID)
+	if err != nil {
+		return err
+	}
+	return s.shell.Close()
+}
+
+// CloseAll 结束所有会话
+func (m *Manager) CloseAll() {
+	m.mu.Lock()
+	sessions := make([]*session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		sessions = append(sessions, s)
+	}
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		_ = s.shell.Close()
+	}
+}
+
+// List 返回所有进行中的会话
+func (m *Manager) List() []*internal.TerminalSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]*internal.TerminalSession, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		info := s.info
+		result = append(result, &info)
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package terminal
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeShell 回显输入的 shell，failWith 非 nil 时以该错误结束输出
+type fakeShell struct {
+	out      *io.PipeReader
+	w        *io.PipeWriter
+	mu       sync.Mutex
+	size     [2]int
+	closed   bool
+	failWith error
+}
+
+func newFakeShell() *fakeShell {
+	r, w := io.Pipe()
+	return &fakeShell{out: r, w: w}
+}
+
+func (f *fakeShell) Read(p []byte) (int, error)  { return f.out.Read(p) }
+func (f *fakeShell) Write(p []byte) (int, error) { return f.w.Write(p) }
+
+func (f *fakeShell) Resize(cols, rows int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.size = [2]int{cols, rows}
+	return nil
+}
+
+func (f *fakeShell) Close() error {
+	f.mu.Lock()
+	f.closed = true
+	err := f.failWith
+	f.mu.Unlock()
+	if err != nil {
+		return f.w.CloseWithError(err)
+	}
+	return f.w.Close()
+}
+
+func TestManager(t *testing.T) {
+	output := make(chan string, 10)
+	closed := make(chan error, 10)
+	m := NewManager(
+		func(_ string, data []byte) { output <- string(data) },
+		func(_ string, err error) { closed <- err },
+	)
+	waitClosed := func(t *testing.T) error {
+		t.Helper()
+		select {
+		case err := <-closed:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for close callback")
+			return nil
+		}
+	}
+
+	t.Run("WriteResizeClose", func(t *testing.T) {
+		shell := newFakeShell()
+		info := m.Open("n1", shell)
+		if info.NodeID != "n1" || info.ID == "" || len(m.List()) != 1 {
+			t.Fatalf("Unexpected session %+v", info)
+		}
+
+		go func() { _ = m.Write(info.ID, []byte("ls\r")) }()
+		select {
+		case got := <-output:
+			if got != "ls\r" {
+				t.Errorf("Unexpected output %q", got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for output")
+		}
+
+		if err := m.Resize(info.ID, 100, 30); err != nil || shell.size != [2]int{100, 30} {
+			t.Errorf("Resize failed: %v, size %v", err, shell.size)
+		}
+		if err := m.Resize(info.ID, 0, 30); err == nil {
+			t.Error("Expected invalid size error")
+		}
+
+		if err := m.Close(info.ID); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if err := waitClosed(t); err != nil {
+			t.Errorf("Expected clean close, got %v", err)
+		}
+		if err := m.Write(info.ID, []byte("x")); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("Expected ErrSessionNotFound after close, got %v", err)
+		}
+	})
+
+	t.Run("ConnectionLost", func(t *testing.T) {
+		shell := newFakeShell()
+		shell.failWith = errors.New("connection lost")
+		m.Open("n1", shell)
+		m.CloseAll()
+		if err := waitClosed(t); err == nil || err.Error() != "connection lost" {
+			t.Errorf("Expected connection lost, got %v", err)
+		}
+		if len(m.List()) != 0 {
+			t.Error("Expected no sessions left")
+		}
+	})
+}
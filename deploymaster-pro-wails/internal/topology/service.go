@@ -2,17 +2,153 @@ package topology
 
 import (
 	"deploymaster-pro-wails/internal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // Service 拓扑服务
-type Service struct{}
+// 管理多套部署拓扑（主控机 + 从机），如 prod / staging / DR 集群
+type Service struct {
+	storage    Storage
+	topologies []*internal.Topology
+	mu         sync.RWMutex
+}
+
+// NewService 创建拓扑服务并加载已有拓扑
+func NewService(storage Storage) (*Service, error) {
+	topologies, err := storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		storage:    storage,
+		topologies: topologies,
+	}, nil
+}
+
+func (s *Service) saveLocked() error {
+	return s.storage.Save(s.topologies)
+}
+
+// ListTopologies 获取所有拓扑
+func (s *Service) ListTopologies() []*internal.Topology {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-// NewService 创建拓扑服务
-func NewService() *Service {
-	return &Service{}
+	result := make([]*internal.Topology, len(s.topologies))
+	copy(result, s.topologies)
+	return result
+}
+
+// GetTopology 获取单个拓扑
+func (s *Service) GetTopology(id string) (*internal.Topology, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.topologies {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, ErrTopologyNotFound
+}
+
+// AddTopology 添加拓扑
+func (s *Service) AddTopology(topology *internal.Topology) (*internal.Topology, error) {
+	if err := validate(topology); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if topology.ID == "" {
+		topology.ID = uuid.NewString()
+	}
+	for _, t := range s.topologies {
+		if t.ID == topology.ID {
+			return nil, ErrTopologyExists
+		}
+	}
+
+	created := normalize(*topology)
+	created.CreatedAt = nowString()
+	created.UpdatedAt = created.CreatedAt
+	s.topologies = append(s.topologies, &created)
+	if err := s.saveLocked(); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateTopology 更新拓扑
+func (s *Service) UpdateTopology(topology *internal.Topology) error {
+	if err := validate(topology); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.topologies {
+		if t.ID == topology.ID {
+			updated := normalize(*topology)
+			updated.CreatedAt = t.CreatedAt
+			updated.UpdatedAt = nowString()
+			s.topologies[i] = &updated
+			return s.saveLocked()
+		}
+	}
+	return ErrTopologyNotFound
+}
+
+// DeleteTopology 删除拓扑；是否仍被任务引用由调用方检查
+func (s *Service) DeleteTopology(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.topologies {
+		if t.ID == id {
+			s.topologies = append(s.topologies[:i], s.topologies[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return ErrTopologyNotFound
+}
+
+// RemoveNode 节点删除后从各拓扑的从机列表中移除
+// 作为主控机被引用的拓扑保持不变，运行时报告节点不存在
+func (s *Service) RemoveNode(nodeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for i, t := range s.topologies {
+		slaves := make([]string, 0, len(t.SlaveIDs))
+		for _, id := range t.SlaveIDs {
+			if id != nodeID {
+				slaves = append(slaves, id)
+			}
+		}
+		if len(slaves) != len(t.SlaveIDs) {
+			updated := *t
+			updated.SlaveIDs = slaves
+			updated.UpdatedAt = nowString()
+			s.topologies[i] = &updated
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveLocked()
 }
 
 // GetTopologyData 获取拓扑结构数据
+// 按节点 IsMaster 标记汇总，用于未定义拓扑时的默认视图；存在多个主控节点时取第一个
 func (s *Service) GetTopologyData(nodes []*internal.Node) *internal.TopologyData {
 	topology := &internal.TopologyData{
 		Total:  len(nodes),
@@ -22,7 +158,9 @@ func (s *Service) GetTopologyData(nodes []*internal.Node) *internal.TopologyData
 	// 分离主节点和从节点
 	for _, node := range nodes {
 		if node.IsMaster {
-			topology.Master = node
+			if topology.Master == nil {
+				topology.Master = node
+			}
 		} else {
 			topology.Slaves = append(topology.Slaves, node)
 		}
@@ -30,3 +168,50 @@ func (s *Service) GetTopologyData(nodes []*internal.Node) *internal.TopologyData
 
 	return topology
 }
+
+// Resolve 将拓扑解析为可视化数据
+// slaves 为已解析的从机（显式列表与选择器合并后的结果）
+func (s *Service) Resolve(topology *internal.Topology, master *internal.Node, slaves []*internal.Node) *internal.TopologyData {
+	data := &internal.TopologyData{
+		ID:     topology.ID,
+		Name:   topology.Name,
+		Master: master,
+		Slaves: slaves,
+		Total:  len(slaves),
+	}
+	if data.Slaves == nil {
+		data.Slaves = make([]*internal.Node, 0)
+	}
+	if master != nil {
+		data.Total++
+	}
+	return data
+}
+
+func validate(topology *internal.Topology) error {
+	if strings.TrimSpace(topology.Name) == "" || strings.TrimSpace(topology.MasterID) == "" {
+		return ErrInvalidTopology
+	}
+	return nil
+}
+
+// normalize 去除从机列表中的重复项与主控节点
+func normalize(topology internal.Topology) internal.Topology {
+	topology.Name = strings.TrimSpace(topology.Name)
+	topology.SlaveSelector = strings.TrimSpace(topology.SlaveSelector)
+	seen := map[string]bool{topology.MasterID: true}
+	slaves := make([]string, 0, len(topology.SlaveIDs))
+	for _, id := range topology.SlaveIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		slaves = append(slaves, id)
+	}
+	topology.SlaveIDs = slaves
+	return topology
+}
+
+func nowString() string {
+	return time.Now().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,98 @@
+package topology
+
+import (
+	"deploymaster-pro-wails/internal"
+	"errors"
+	"testing"
+)
+
+func TestTopologyService(t *testing.T) {
+	tmpDir := t.TempDir()
+	storage, err := NewJSONStorage(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	service, err := NewService(storage)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	var prodID string
+
+	t.Run("AddTopology", func(t *testing.T) {
+		prod, err := service.AddTopology(&internal.Topology{
+			Name:     "prod",
+			MasterID: "m1",
+			SlaveIDs: []string{"s1", "s2", "s1", "m1"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to add topology: %v", err)
+		}
+		prodID = prod.ID
+		if prod.ID == "" || prod.CreatedAt == "" {
+			t.Errorf("Expected generated ID and timestamps, got %+v", prod)
+		}
+		if len(prod.SlaveIDs) != 2 {
+			t.Errorf("Expected duplicates and master removed from slaves, got %v", prod.SlaveIDs)
+		}
+
+		if _, err := service.AddTopology(&internal.Topology{Name: "staging", MasterID: "m2"}); err != nil {
+			t.Fatalf("Failed to add second topology: %v", err)
+		}
+		if _, err := service.AddTopology(&internal.Topology{Name: "no master"}); !errors.Is(err, ErrInvalidTopology) {
+			t.Errorf("Expected ErrInvalidTopology, got %v", err)
+		}
+	})
+
+	t.Run("UpdateTopology", func(t *testing.T) {
+		err := service.UpdateTopology(&internal.Topology{ID: prodID, Name: "prod", MasterID: "m1", SlaveSelector: " env=prod "})
+		if err != nil {
+			t.Fatalf("Failed to update topology: %v", err)
+		}
+		got, _ := service.GetTopology(prodID)
+		if got.SlaveSelector != "env=prod" || got.CreatedAt == "" {
+			t.Errorf("Unexpected topology after update: %+v", got)
+		}
+		if err := service.UpdateTopology(&internal.Topology{ID: "missing", Name: "x", MasterID: "m"}); !errors.Is(err, ErrTopologyNotFound) {
+			t.Errorf("Expected ErrTopologyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("RemoveNode", func(t *testing.T) {
+		_ = service.UpdateTopology(&internal.Topology{ID: prodID, Name: "prod", MasterID: "m1", SlaveIDs: []string{"s1", "s2"}})
+		if err := service.RemoveNode("s1"); err != nil {
+			t.Fatalf("Failed to remove node: %v", err)
+		}
+		got, _ := service.GetTopology(prodID)
+		if len(got.SlaveIDs) != 1 || got.SlaveIDs[0] != "s2" {
+			t.Errorf("Expected s1 removed, got %v", got.SlaveIDs)
+		}
+	})
+
+	t.Run("Persistence", func(t *testing.T) {
+		reloaded, err := NewService(storage)
+		if err != nil {
+			t.Fatalf("Failed to reload: %v", err)
+		}
+		if len(reloaded.ListTopologies()) != 2 {
+			t.Errorf("Expected 2 topologies after reload, got %d", len(reloaded.ListTopologies()))
+		}
+		if err := reloaded.DeleteTopology(prodID); err != nil {
+			t.Fatalf("Failed to delete topology: %v", err)
+		}
+		if _, err := reloaded.GetTopology(prodID); !errors.Is(err, ErrTopologyNotFound) {
+			t.Errorf("Expected deleted topology to be gone, got %v", err)
+		}
+	})
+
+	t.Run("GetTopologyData", func(t *testing.T) {
+		data := service.GetTopologyData([]*internal.Node{
+			{ID: "m1", IsMaster: true},
+			{ID: "m2", IsMaster: true},
+			{ID: "s1"},
+		})
+		if data.Master == nil || data.Master.ID != "m1" || len(data.Slaves) != 1 || data.Total != 3 {
+			t.Errorf("Unexpected default topology view: %+v", data)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package topology
+
+import (
+	"deploymaster-pro-wails/internal"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTopologyNotFound 拓扑不存在
+	ErrTopologyNotFound = errors.New("topology not found")
+	// ErrTopologyExists 拓扑已存在
+	ErrTopologyExists = errors.New("topology already exists")
+	// ErrInvalidTopology 拓扑缺少名称或主控节点
+	ErrInvalidTopology = errors.New("topology requires a name and a master node")
+	// ErrTopologyInUse 拓扑仍被任务引用
+	ErrTopologyInUse = errors.New("topology is referenced by tasks")
+)
+
+// Storage 定义拓扑存储接口
+type Storage interface {
+	Load() ([]*internal.Topology, error)
+	Save(topologies []*internal.Topology) error
+}
+
+// JSONStorage 基于JSON文件的存储实现
+// 存储文件名：topologies.json，与节点数据放在同一数据目录
+type JSONStorage struct {
+	filePath string
+	mu       sync.RWMutex
+}
+
+// NewJSONStorage 创建拓扑存储实例
+func NewJSONStorage(dataDir string) (*JSONStorage, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &JSONStorage{
+		filePath: filepath.Join(dataDir, "topologies.json"),
+	}, nil
+}
+
+// Load 从文件加载拓扑数据
+func (s *JSONStorage) Load() ([]*internal.Topology, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := os.Stat(s.filePath); os.IsNotExist(err) {
+		return []*internal.Topology{}, nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection internal.TopologyCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, err
+	}
+	if collection.Topologies == nil {
+		collection.Topologies = []*internal.Topology{}
+	}
+	return collection.Topologies, nil
+}
+
+// Save 保存拓扑数据到文件
+func (s *JSONStorage) Save(topologies []*internal.Topology) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collection := internal.TopologyCollection{
+		Topologies: topologies,
+		UpdatedAt:  time.Now(),
+	}
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, s.filePath)
+}
@@ -0,0 +1,57 @@
+package transfer
+
+import (
+	"io"
+	"os"
+	"sort"
+)
+
+// Listing 远程目录内容
+type Listing struct {
+	Path    string      `json:"path"`    // 解析后的绝对路径
+	Entries []*FileInfo `json:"entries"` // 目录在前，同类按名称排序
+}
+
+// Browser 支持浏览与下载的传输通道，SFTP 与本地目录实现该接口
+type Browser interface {
+	Transport
+	// List 列出远程目录，remoteDir 为空时为登录后的初始目录
+	List(remoteDir string) (*Listing, error)
+	// Download 下载单个远程文件到本地路径；progress 可为 nil
+	Download(remoteFile, localFile string, progress ProgressFunc) error
+}
+
+// 编译期校验接口实现
+var (
+	_ Browser = (*SFTP)(nil)
+	_ Browser = (*Local)(nil)
+)
+
+// sortEntries 目录在前，同类按名称排序
+func sortEntries(entries []*FileInfo) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].IsDir != entries[j].IsDir {
+			return entries[i].IsDir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// saveLocal 将 src 写入本地文件，先写临时文件再重命名，失败时不留下不完整的文件
+func saveLocal(src io.Reader, size int64, localFile string, progress ProgressFunc) error {
+	tmpFile := localFile + ".part"
+	dst, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, NewProgressReader(src, size, progress)); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmpFile)
+		return err
+	}
+	return os.Rename(tmpFile, localFile)
+}
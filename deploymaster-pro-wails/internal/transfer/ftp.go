@@ -0,0 +1,172 @@
+package transfer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/fs"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// DefaultFTPPort FTP 默认端口
+const DefaultFTPPort = 21
+
+// FTPConfig FTP/FTPS 连接参数
+type FTPConfig struct {
+	Host     string
+	Port     int // 为 0 时使用 DefaultFTPPort
+	User     string
+	Password string
+	TLS      bool          // 显式 FTPS：AUTH TLS 升级控制连接，数据连接同样加密
+	Timeout  time.Duration // 为 0 时使用 10 秒
+}
+
+// FTP 基于 FTP/FTPS 的传输通道，数据连接使用被动模式（EPSV，失败时回退 PASV）
+type FTP struct {
+	cfg  FTPConfig
+	conn *ftp.ServerConn
+	home string // 登录后的初始目录，检测目录是否存在后切回
+}
+
+// NewFTP 创建 FTP 传输通道，Connect 时连接并登录
+func NewFTP(cfg FTPConfig) *FTP {
+	return &FTP{cfg: cfg}
+}
+
+// DialFTP 连接并登录 FTP 服务器
+func DialFTP(cfg FTPConfig) (*FTP, error) {
+	f := NewFTP(cfg)
+	if err := f.Connect(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Connect 连接并登录，记录初始目录
+func (f *FTP) Connect() error {
+	if f.conn != nil {
+		return nil
+	}
+	cfg := f.cfg
+	port := cfg.Port
+	if port == 0 {
+		port = DefaultFTPPort
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	options := []ftp.DialOption{ftp.DialWithTimeout(timeout)}
+	if cfg.TLS {
+		options = append(options, ftp.DialWithExplicitTLS(&tls.Config{
+			ServerName:         cfg.Host,
+			InsecureSkipVerify: true, // 注意：老旧设备多为自签名证书，生产环境应该验证证书
+		}))
+	}
+
+	conn, err := ftp.Dial(net.JoinHostPort(cfg.Host, strconv.Itoa(port)), options...)
+	if err != nil {
+		return fmt.Errorf("ftp dial failed: %w", err)
+	}
+	if err := conn.Login(cfg.User, cfg.Password); err != nil {
+		_ = conn.Quit()
+		return fmt.Errorf("ftp login failed: %w", err)
+	}
+
+	home, err := conn.CurrentDir()
+	if err != nil {
+		home = "/"
+	}
+	f.conn, f.home = conn, home
+	return nil
+}
+
+// MkdirAll 逐级创建远程目录；MKD 失败时以 CWD 判断目录是否已存在
+func (f *FTP) MkdirAll(remoteDir string) error {
+	dir := path.Clean(remoteDir)
+	if dir == "." || dir == "/" {
+		return nil
+	}
+
+	current := ""
+	if strings.HasPrefix(dir, "/") {
+		current = "/"
+	}
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		current = path.Join(current, part)
+		if err := f.conn.MakeDir(current); err == nil {
+			continue
+		} else if cdErr := f.conn.ChangeDir(current); cdErr != nil {
+			return fmt.Errorf("ftp mkdir %s failed: %w", current, err)
+		}
+		_ = f.conn.ChangeDir(f.home)
+	}
+	return nil
+}
+
+// Upload 以二进制模式上传单个文件
+func (f *FTP) Upload(localFile, remoteFile string, progress ProgressFunc) error {
+	src, size, err := openLocal(localFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := f.conn.Stor(remoteFile, NewProgressReader(src, size, progress)); err != nil {
+		return fmt.Errorf("ftp upload %s failed: %w", remoteFile, err)
+	}
+	return nil
+}
+
+// Stat 查询远程路径；服务器不支持 MLST 时依次以 SIZE、CWD 判断文件与目录
+func (f *FTP) Stat(remotePath string) (*FileInfo, error) {
+	name := path.Base(path.Clean(remotePath))
+	if entry, err := f.conn.GetEntry(remotePath); err == nil {
+		info := &FileInfo{Name: name, Size: int64(entry.Size), ModTime: entry.Time}
+		if entry.Type == ftp.EntryTypeFolder {
+			info.IsDir = true
+			info.Mode = fs.ModeDir
+		}
+		return info, nil
+	}
+
+	if size, err := f.conn.FileSize(remotePath); err == nil {
+		info := &FileInfo{Name: name, Size: size}
+		if modTime, err := f.conn.GetTime(remotePath); err == nil {
+			info.ModTime = modTime
+		}
+		return info, nil
+	}
+	if err := f.conn.ChangeDir(remotePath); err == nil {
+		_ = f.conn.ChangeDir(f.home)
+		return &FileInfo{Name: name, Mode: fs.ModeDir, IsDir: true}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: remotePath, Err: fs.ErrNotExist}
+}
+
+// Remove 删除远程文件或目录（递归）
+func (f *FTP) Remove(remotePath string) error {
+	info, err := f.Stat(remotePath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir {
+		return f.conn.Delete(remotePath)
+	}
+	defer func() { _ = f.conn.ChangeDir(f.home) }()
+	return f.conn.RemoveDirRecur(remotePath)
+}
+
+// Close 退出登录并关闭连接
+func (f *FTP) Close() error {
+	if f.conn == nil {
+		return nil
+	}
+	return f.conn.Quit()
+}
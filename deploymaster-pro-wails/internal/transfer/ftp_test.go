@@ -0,0 +1,230 @@
+package transfer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeFTPServer 进程内最小 FTP 服务器：支持登录、被动模式（EPSV）、MKD/CWD/PWD、SIZE/DELE 与 STOR，
+// 文件写入 root 目录
+type fakeFTPServer struct {
+	root     string
+	user     string
+	password string
+	ln       net.Listener
+}
+
+func startFakeFTPServer(t *testing.T, user, password string) *fakeFTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := &fakeFTPServer{root: t.TempDir(), user: user, password: password, ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeFTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeFTPServer) local(p string) string {
+	return filepath.Join(s.root, filepath.FromSlash(strings.TrimPrefix(p, "/")))
+}
+
+func (s *fakeFTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...any) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var data net.Listener
+	loggedIn := false
+	reply("220 fake ftp ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch strings.ToUpper(cmd) {
+		case "USER":
+			reply("331 password required")
+		case "PASS":
+			if arg != s.password {
+				reply("530 login incorrect")
+				continue
+			}
+			loggedIn = true
+			reply("230 logged in")
+		case "FEAT":
+			reply("502 not implemented")
+		case "TYPE":
+			reply("200 type set")
+		case "PWD":
+			reply(`257 "/" is current directory`)
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			if !loggedIn {
+				reply("530 not logged in")
+				continue
+			}
+			switch strings.ToUpper(cmd) {
+			case "EPSV":
+				if data, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+					reply("425 cannot open data connection")
+					continue
+				}
+				reply("229 Entering Extended Passive Mode (|||%d|)", data.Addr().(*net.TCPAddr).Port)
+			case "MKD":
+				if err := os.Mkdir(s.local(arg), 0755); err != nil {
+					reply("550 %v", err)
+					continue
+				}
+				reply(`257 "%s" created`, arg)
+			case "CWD":
+				if info, err := os.Stat(s.local(arg)); err != nil || !info.IsDir() {
+					reply("550 no such directory")
+					continue
+				}
+				reply("250 ok")
+			case "SIZE":
+				info, err := os.Stat(s.local(arg))
+				if err != nil || info.IsDir() {
+					reply("550 no such file")
+					continue
+				}
+				reply("213 %d", info.Size())
+			case "DELE":
+				if err := os.Remove(s.local(arg)); err != nil {
+					reply("550 %v", err)
+					continue
+				}
+				reply("250 deleted")
+			case "STOR":
+				if data == nil {
+					reply("425 use EPSV first")
+					continue
+				}
+				reply("150 opening data connection")
+				dc, err := data.Accept()
+				_ = data.Close()
+				data = nil
+				if err != nil {
+					reply("425 %v", err)
+					continue
+				}
+				f, err := os.Create(s.local(arg))
+				if err != nil {
+					_ = dc.Close()
+					reply("553 %v", err)
+					continue
+				}
+				_, _ = io.Copy(f, dc)
+				_ = f.Close()
+				_ = dc.Close()
+				reply("226 transfer complete")
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}
+}
+
+func TestFTPTransport(t *testing.T) {
+	server := startFakeFTPServer(t, "deploy", "secret")
+
+	src := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(src, "conf"), 0755)
+	_ = os.WriteFile(filepath.Join(src, "app.exe"), []byte("binary"), 0644)
+	_ = os.WriteFile(filepath.Join(src, "conf", "app.ini"), []byte("[app]\n"), 0644)
+
+	t.Run("UploadDirectory", func(t *testing.T) {
+		ft, err := DialFTP(FTPConfig{Host: "127.0.0.1", Port: server.port(), User: "deploy", Password: "secret"})
+		if err != nil {
+			t.Fatalf("Failed to dial: %v", err)
+		}
+		defer ft.Close()
+
+		if err := UploadPath(ft, src, "/srv/app", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		// 目录已存在时再次上传不应失败
+		if err := UploadPath(ft, src, "/srv/app", nil); err != nil {
+			t.Fatalf("Second upload failed: %v", err)
+		}
+		for rel, want := range map[string]string{"srv/app/app.exe": "binary", "srv/app/conf/app.ini": "[app]\n"} {
+			got, err := os.ReadFile(filepath.Join(server.root, rel))
+			if err != nil || string(got) != want {
+				t.Errorf("%s: got %q (%v), want %q", rel, got, err, want)
+			}
+		}
+	})
+
+	t.Run("UploadFile", func(t *testing.T) {
+		ft, err := DialFTP(FTPConfig{Host: "127.0.0.1", Port: server.port(), User: "deploy", Password: "secret"})
+		if err != nil {
+			t.Fatalf("Failed to dial: %v", err)
+		}
+		defer ft.Close()
+
+		if err := UploadPath(ft, filepath.Join(src, "app.exe"), "/pkg/v2/app.exe", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		if got, _ := os.ReadFile(filepath.Join(server.root, "pkg", "v2", "app.exe")); string(got) != "binary" {
+			t.Errorf("Unexpected file content %q", got)
+		}
+	})
+
+	t.Run("StatAndRemove", func(t *testing.T) {
+		ft, err := DialFTP(FTPConfig{Host: "127.0.0.1", Port: server.port(), User: "deploy", Password: "secret"})
+		if err != nil {
+			t.Fatalf("Failed to dial: %v", err)
+		}
+		defer ft.Close()
+
+		if err := UploadPath(ft, filepath.Join(src, "app.exe"), "/stat/app.exe", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		info, err := ft.Stat("/stat/app.exe")
+		if err != nil || info.IsDir || info.Size != int64(len("binary")) {
+			t.Fatalf("Unexpected file info %+v (%v)", info, err)
+		}
+		if info, err := ft.Stat("/stat"); err != nil || !info.IsDir {
+			t.Fatalf("Expected directory, got %+v (%v)", info, err)
+		}
+		if err := ft.Remove("/stat/app.exe"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if _, err := ft.Stat("/stat/app.exe"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected ErrNotExist after remove, got %v", err)
+		}
+	})
+
+	t.Run("LoginFailed", func(t *testing.T) {
+		if _, err := DialFTP(FTPConfig{Host: "127.0.0.1", Port: server.port(), User: "deploy", Password: "wrong"}); err == nil {
+			t.Error("Expected login failure")
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Local 本地目录传输通道，远程路径映射到 Root 之下，用于演练部署而不连接远程节点
+type Local struct {
+	Root string
+}
+
+// NewLocal 创建以 root 为远程根目录的本地传输通道
+func NewLocal(root string) *Local {
+	return &Local{Root: root}
+}
+
+// local 将远程路径映射为 Root 下的本地路径，不会越出 Root
+func (l *Local) local(remotePath string) string {
+	clean := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(remotePath)), "/")
+	return filepath.Join(l.Root, filepath.FromSlash(clean))
+}
+
+// Connect 创建根目录
+func (l *Local) Connect() error {
+	return os.MkdirAll(l.Root, 0755)
+}
+
+// MkdirAll 递归创建目录
+func (l *Local) MkdirAll(remoteDir string) error {
+	return os.MkdirAll(l.local(remoteDir), 0755)
+}
+
+// Upload 复制单个文件
+func (l *Local) Upload(localFile, remoteFile string, progress ProgressFunc) error {
+	src, size, err := openLocal(localFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(l.local(remoteFile))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, NewProgressReader(src, size, progress)); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Stat 查询路径
+func (l *Local) Stat(remotePath string) (*FileInfo, error) {
+	info, err := os.Stat(l.local(remotePath))
+	if err != nil {
+		return nil, err
+	}
+	return fileInfoFrom(info), nil
+}
+
+// Remove 删除文件或目录（递归）；路径不存在时返回错误，与远程实现一致
+func (l *Local) Remove(remotePath string) error {
+	p := l.local(remotePath)
+	if _, err := os.Lstat(p); err != nil {
+		return err
+	}
+	return os.RemoveAll(p)
+}
+
+// List 列出目录，remoteDir 为空时为根目录
+func (l *Local) List(remoteDir string) (*Listing, error) {
+	items, err := os.ReadDir(l.local(remoteDir))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*FileInfo, 0, len(items))
+	for _, item := range items {
+		info, err := item.Info()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileInfoFrom(info))
+	}
+	sortEntries(entries)
+	return &Listing{Path: path.Clean("/" + filepath.ToSlash(remoteDir)), Entries: entries}, nil
+}
+
+// Download 复制单个文件到本地路径
+func (l *Local) Download(remoteFile, localFile string, progress ProgressFunc) error {
+	src, size, err := openLocal(l.local(remoteFile))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if info, err := src.Stat(); err == nil && info.IsDir() {
+		return fmt.Errorf("%s is a directory", remoteFile)
+	}
+	return saveLocal(src, size, localFile, progress)
+}
+
+// Close 无需释放资源
+func (l *Local) Close() error {
+	return nil
+}
@@ -0,0 +1,157 @@
+package transfer
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mock 内存传输通道，记录上传的文件与目录，供测试使用
+type Mock struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+
+	// 非 nil 时对应操作直接返回该错误
+	ConnectErr error
+	UploadErr  error
+
+	Connected bool
+	Closed    bool
+}
+
+// NewMock 创建空的内存传输通道
+func NewMock() *Mock {
+	return &Mock{
+		files: make(map[string][]byte),
+		dirs:  map[string]bool{"/": true},
+	}
+}
+
+func mockPath(p string) string {
+	return path.Clean("/" + p)
+}
+
+// Connect 标记为已连接
+func (m *Mock) Connect() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ConnectErr != nil {
+		return m.ConnectErr
+	}
+	m.Connected = true
+	return nil
+}
+
+// MkdirAll 记录目录及其所有上级目录
+func (m *Mock) MkdirAll(remoteDir string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for dir := mockPath(remoteDir); ; dir = path.Dir(dir) {
+		m.dirs[dir] = true
+		if dir == "/" {
+			return nil
+		}
+	}
+}
+
+// Upload 将文件内容读入内存
+func (m *Mock) Upload(localFile, remoteFile string, progress ProgressFunc) error {
+	m.mu.Lock()
+	uploadErr := m.UploadErr
+	m.mu.Unlock()
+	if uploadErr != nil {
+		return uploadErr
+	}
+	src, size, err := openLocal(localFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(NewProgressReader(src, size, progress))
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := mockPath(remoteFile)
+	if !m.dirs[path.Dir(p)] {
+		return &fs.PathError{Op: "upload", Path: remoteFile, Err: fs.ErrNotExist}
+	}
+	m.files[p] = data
+	return nil
+}
+
+// Stat 查询已记录的文件或目录
+func (m *Mock) Stat(remotePath string) (*FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := mockPath(remotePath)
+	if data, ok := m.files[p]; ok {
+		return &FileInfo{Name: path.Base(p), Size: int64(len(data)), Mode: 0644}, nil
+	}
+	if m.dirs[p] {
+		return &FileInfo{Name: path.Base(p), Mode: fs.ModeDir | 0755, IsDir: true}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: remotePath, Err: fs.ErrNotExist}
+}
+
+// Remove 删除文件或目录及其下所有内容
+func (m *Mock) Remove(remotePath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := mockPath(remotePath)
+	if _, ok := m.files[p]; ok {
+		delete(m.files, p)
+		return nil
+	}
+	if !m.dirs[p] {
+		return &fs.PathError{Op: "remove", Path: remotePath, Err: fs.ErrNotExist}
+	}
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	for f := range m.files {
+		if strings.HasPrefix(f, prefix) {
+			delete(m.files, f)
+		}
+	}
+	for d := range m.dirs {
+		if d == p || strings.HasPrefix(d, prefix) {
+			delete(m.dirs, d)
+		}
+	}
+	m.dirs["/"] = true
+	return nil
+}
+
+// Close 标记为已关闭
+func (m *Mock) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Closed = true
+	return nil
+}
+
+// File 返回已上传文件的内容
+func (m *Mock) File(remotePath string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[mockPath(remotePath)]
+	return data, ok
+}
+
+// Files 返回已上传文件的路径，按字典序排列
+func (m *Mock) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	paths := make([]string, 0, len(m.files))
+	for p := range m.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,135 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pkg/sftp"
+)
+
+// SFTPOpener 建立 SFTP 会话；返回的 io.Closer 为底层连接（可为 nil），随传输通道一并关闭
+type SFTPOpener func() (*sftp.Client, io.Closer, error)
+
+// SFTP 基于 SFTP 子系统的传输通道
+type SFTP struct {
+	open   SFTPOpener
+	client *sftp.Client
+	conn   io.Closer
+}
+
+// NewSFTP 创建 SFTP 传输通道，Connect 时调用 open 建立会话
+func NewSFTP(open SFTPOpener) *SFTP {
+	return &SFTP{open: open}
+}
+
+// WrapSFTP 包装已建立的 SFTP 客户端，Connect 为空操作
+func WrapSFTP(client *sftp.Client) *SFTP {
+	return &SFTP{client: client}
+}
+
+// Connect 建立 SFTP 会话
+func (s *SFTP) Connect() error {
+	if s.client != nil {
+		return nil
+	}
+	if s.open == nil {
+		return errors.New("sftp transport has no opener")
+	}
+	client, conn, err := s.open()
+	if err != nil {
+		return err
+	}
+	s.client, s.conn = client, conn
+	return nil
+}
+
+// MkdirAll 递归创建远程目录
+func (s *SFTP) MkdirAll(remoteDir string) error {
+	return s.client.MkdirAll(remoteDir)
+}
+
+// Upload 上传单个文件
+func (s *SFTP) Upload(localFile, remoteFile string, progress ProgressFunc) error {
+	src, size, err := openLocal(localFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := s.client.Create(remoteFile)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, NewProgressReader(src, size, progress))
+	return err
+}
+
+// Stat 查询远程路径
+func (s *SFTP) Stat(remotePath string) (*FileInfo, error) {
+	info, err := s.client.Stat(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	return fileInfoFrom(info), nil
+}
+
+// Remove 删除远程文件或目录
+func (s *SFTP) Remove(remotePath string) error {
+	return s.client.RemoveAll(remotePath)
+}
+
+// List 列出远程目录
+func (s *SFTP) List(remoteDir string) (*Listing, error) {
+	if remoteDir == "" {
+		remoteDir = "."
+	}
+	dir, err := s.client.RealPath(remoteDir)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := s.client.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*FileInfo, 0, len(infos))
+	for _, info := range infos {
+		entries = append(entries, fileInfoFrom(info))
+	}
+	sortEntries(entries)
+	return &Listing{Path: dir, Entries: entries}, nil
+}
+
+// Download 下载单个远程文件
+func (s *SFTP) Download(remoteFile, localFile string, progress ProgressFunc) error {
+	src, err := s.client.Open(remoteFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", remoteFile)
+	}
+	return saveLocal(src, info.Size(), localFile, progress)
+}
+
+// Close 关闭 SFTP 会话及底层连接
+func (s *SFTP) Close() error {
+	var err error
+	if s.client != nil {
+		err = s.client.Close()
+	}
+	if s.conn != nil {
+		if cerr := s.conn.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
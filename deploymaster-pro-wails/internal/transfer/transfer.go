@@ -0,0 +1,187 @@
+package transfer
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// ProgressFunc 上传进度回调，written 为已写入字节数，total 为总字节数
+type ProgressFunc func(written, total int64)
+
+// FileInfo 远程文件信息
+type FileInfo struct {
+	Name    string      `json:"name"`
+	Size    int64       `json:"size"`
+	Mode    fs.FileMode `json:"mode"`
+	ModTime time.Time   `json:"modTime"`
+	IsDir   bool        `json:"isDir"`
+}
+
+func fileInfoFrom(info fs.FileInfo) *FileInfo {
+	return &FileInfo{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		Mode:    info.Mode(),
+		ModTime: info.ModTime(),
+		IsDir:   info.IsDir(),
+	}
+}
+
+// Transport 文件传输通道，SFTP、FTP、本地目录等实现该接口，
+// 目录递归等上传逻辑由 UploadPath 统一实现
+type Transport interface {
+	// Connect 建立连接，其余方法需在 Connect 成功后调用
+	Connect() error
+	// MkdirAll 递归创建远程目录，目录已存在时不报错
+	MkdirAll(remoteDir string) error
+	// Upload 上传单个本地文件到远程路径，父目录需已存在；progress 可为 nil
+	Upload(localFile, remoteFile string, progress ProgressFunc) error
+	// Stat 查询远程路径，不存在时返回的错误满足 errors.Is(err, fs.ErrNotExist)
+	Stat(remotePath string) (*FileInfo, error)
+	// Remove 删除远程文件或目录（递归）
+	Remove(remotePath string) error
+	// Close 关闭连接
+	Close() error
+}
+
+// UploadPath 上传本地路径到远端，t 需已连接
+// localPath 可以是文件或目录，remotePath 为目标目录或文件路径；progress 汇报所有文件的累计字节数
+func UploadPath(t Transport, localPath, remotePath string, progress ProgressFunc) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return uploadDir(t, localPath, remotePath, progress)
+	}
+
+	if err := t.MkdirAll(path.Dir(filepath.ToSlash(remotePath))); err != nil {
+		return err
+	}
+	return t.Upload(localPath, remotePath, progress)
+}
+
+func uploadDir(t Transport, localDir, remoteDir string, progress ProgressFunc) error {
+	var total int64
+	if progress != nil {
+		err := filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := os.Stat(p)
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				total += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := t.MkdirAll(remoteDir); err != nil {
+		return err
+	}
+
+	var done int64
+	return filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		remotePath := filepath.ToSlash(filepath.Join(remoteDir, rel))
+		if d.IsDir() {
+			return t.MkdirAll(remotePath)
+		}
+		if skip, err := IsLinkedDir(p, d); err != nil || skip {
+			return err
+		}
+
+		var fileProgress ProgressFunc
+		if progress != nil {
+			fileProgress = func(written, _ int64) { progress(done+written, total) }
+		}
+		var written int64
+		if err := t.Upload(p, remotePath, countingProgress(&written, fileProgress)); err != nil {
+			return err
+		}
+		done += written
+		return nil
+	})
+}
+
+// IsLinkedDir 判断目录遍历条目是否为指向目录的符号链接
+// 上传目录时符号链接指向的文件按内容上传，指向的目录不跟随，避免链接环与重复内容
+func IsLinkedDir(p string, d fs.DirEntry) (bool, error) {
+	if d.Type()&fs.ModeSymlink == 0 {
+		return false, nil
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// countingProgress 记录单个文件的已写入字节数并转发给 next
+func countingProgress(written *int64, next ProgressFunc) ProgressFunc {
+	return func(n, total int64) {
+		*written = n
+		if next != nil {
+			next(n, total)
+		}
+	}
+}
+
+// progressReader 读取时汇报累计字节数
+type progressReader struct {
+	r        io.Reader
+	written  int64
+	total    int64
+	progress ProgressFunc
+}
+
+// NewProgressReader 包装 r，每次读取后以累计字节数回调 progress；progress 为 nil 时原样返回 r
+func NewProgressReader(r io.Reader, total int64, progress ProgressFunc) io.Reader {
+	if progress == nil {
+		return r
+	}
+	return &progressReader{r: r, total: total, progress: progress}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.written += int64(n)
+		p.progress(p.written, p.total)
+	}
+	return n, err
+}
+
+// openLocal 打开本地文件并返回其大小
+func openLocal(localFile string) (*os.File, int64, error) {
+	src, err := os.Open(localFile)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := src.Stat()
+	if err != nil {
+		_ = src.Close()
+		return nil, 0, err
+	}
+	return src, info.Size(), nil
+}
@@ -0,0 +1,186 @@
+package transfer
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+)
+
+func writeSourceTree(t *testing.T) string {
+	t.Helper()
+	src := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(src, "conf", "empty"), 0755)
+	_ = os.WriteFile(filepath.Join(src, "app.exe"), []byte("binary"), 0644)
+	_ = os.WriteFile(filepath.Join(src, "conf", "app.ini"), []byte("[app]\n"), 0644)
+	return src
+}
+
+func TestUploadPath(t *testing.T) {
+	src := writeSourceTree(t)
+
+	t.Run("Progress", func(t *testing.T) {
+		m := NewMock()
+		var last, total int64
+		err := UploadPath(m, src, "/srv/app", func(written, all int64) {
+			if written < last {
+				t.Errorf("Progress went backwards: %d after %d", written, last)
+			}
+			last, total = written, all
+		})
+		if err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		want := int64(len("binary") + len("[app]\n"))
+		if last != want || total != want {
+			t.Errorf("Expected final progress %d/%d, got %d/%d", want, want, last, total)
+		}
+		if info, err := m.Stat("/srv/app/conf/empty"); err != nil || !info.IsDir {
+			t.Errorf("Expected empty directory to be created, got %+v (%v)", info, err)
+		}
+		if data, ok := m.File("/srv/app/conf/app.ini"); !ok || string(data) != "[app]\n" {
+			t.Errorf("Unexpected file content %q", data)
+		}
+	})
+
+	t.Run("Symlinks", func(t *testing.T) {
+		linked := writeSourceTree(t)
+		_ = os.Symlink("conf", filepath.Join(linked, "conf-link"))
+		_ = os.Symlink("..", filepath.Join(linked, "conf", "loop"))
+		_ = os.Symlink("app.exe", filepath.Join(linked, "app-link.exe"))
+
+		m := NewMock()
+		if err := UploadPath(m, linked, "/srv/app", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		if data, ok := m.File("/srv/app/app-link.exe"); !ok || string(data) != "binary" {
+			t.Errorf("Expected linked file uploaded by content, got %q", data)
+		}
+		for _, p := range []string{"/srv/app/conf-link", "/srv/app/conf/loop"} {
+			if _, err := m.Stat(p); err == nil {
+				t.Errorf("%s: expected linked directory to be skipped", p)
+			}
+		}
+	})
+
+	t.Run("UploadError", func(t *testing.T) {
+		m := NewMock()
+		m.UploadErr = errors.New("disk full")
+		if err := UploadPath(m, src, "/srv/app", nil); err == nil || err.Error() != "disk full" {
+			t.Errorf("Expected upload error, got %v", err)
+		}
+	})
+}
+
+func TestLocalTransport(t *testing.T) {
+	src := writeSourceTree(t)
+	root := t.TempDir()
+
+	var tr Transport = NewLocal(root)
+	if err := tr.Connect(); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer tr.Close()
+
+	t.Run("Upload", func(t *testing.T) {
+		if err := UploadPath(tr, src, "/srv/app", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		got, err := os.ReadFile(filepath.Join(root, "srv", "app", "conf", "app.ini"))
+		if err != nil || string(got) != "[app]\n" {
+			t.Errorf("Unexpected file content %q (%v)", got, err)
+		}
+		info, err := tr.Stat("/srv/app/app.exe")
+		if err != nil || info.IsDir || info.Size != int64(len("binary")) {
+			t.Errorf("Unexpected file info %+v (%v)", info, err)
+		}
+	})
+
+	t.Run("StaysUnderRoot", func(t *testing.T) {
+		if err := UploadPath(tr, filepath.Join(src, "app.exe"), "../../escape/app.exe", nil); err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "escape", "app.exe")); err != nil {
+			t.Errorf("Expected file under root: %v", err)
+		}
+	})
+
+	t.Run("ListAndDownload", func(t *testing.T) {
+		listing, err := NewLocal(root).List("/srv/app")
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if listing.Path != "/srv/app" || len(listing.Entries) != 2 || !listing.Entries[0].IsDir || listing.Entries[1].Name != "app.exe" {
+			t.Errorf("Unexpected listing %+v", listing)
+		}
+
+		dst := filepath.Join(t.TempDir(), "app.exe")
+		if err := NewLocal(root).Download("/srv/app/app.exe", dst, nil); err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if got, _ := os.ReadFile(dst); string(got) != "binary" {
+			t.Errorf("Unexpected downloaded content %q", got)
+		}
+		if err := NewLocal(root).Download("/srv/app/conf", dst, nil); err == nil {
+			t.Error("Expected error downloading a directory")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		if err := tr.Remove("/srv/app"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if _, err := tr.Stat("/srv/app"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected ErrNotExist after remove, got %v", err)
+		}
+		if err := tr.Remove("/srv/app"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected ErrNotExist removing missing path, got %v", err)
+		}
+	})
+}
+
+func TestSFTPBrowser(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, sftp.InMemHandler())
+	go func() { _ = server.Serve() }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	if err != nil {
+		t.Fatalf("Failed to create sftp client: %v", err)
+	}
+	tr := WrapSFTP(client)
+	defer tr.Close()
+
+	if err := UploadPath(tr, writeSourceTree(t), "/srv/app", nil); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		listing, err := tr.List("/srv/app/../app")
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if listing.Path != "/srv/app" || len(listing.Entries) != 2 || listing.Entries[0].Name != "conf" || listing.Entries[1].Size != int64(len("binary")) {
+			t.Errorf("Unexpected listing %+v", listing)
+		}
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "app.exe")
+		var written int64
+		if err := tr.Download("/srv/app/app.exe", dst, func(n, _ int64) { written = n }); err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if got, _ := os.ReadFile(dst); string(got) != "binary" || written != int64(len("binary")) {
+			t.Errorf("Unexpected download %q, progress %d", got, written)
+		}
+		if err := tr.Download("/srv/app/missing", dst, nil); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected ErrNotExist, got %v", err)
+		}
+	})
+}